@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// writeGitHubActions publica o resultado para os próximos passos do
+// workflow quando a ferramenta roda no GitHub Actions: os outputs do passo
+// (version, previous_version, increment, released) em GITHUB_OUTPUT e uma
+// tabela dos commits analisados em GITHUB_STEP_SUMMARY.
+// Fora do Actions (variáveis não definidas), não faz nada.
+func writeGitHubActions(result releaseResult) error {
+	if path := os.Getenv("GITHUB_OUTPUT"); path != "" {
+		var sb strings.Builder
+		// Em um dry-run nada foi publicado: released=false
+		writeActionsOutputs(&sb, "", result.targetResult, result.DryRun)
+		fmt.Fprintf(&sb, "released=%t\n", result.Released && !result.DryRun)
+		for _, pkg := range result.Packages {
+			writeActionsOutputs(&sb, strings.ToLower(envPrefix(pkg.Package)), pkg, result.DryRun)
+		}
+		if err := appendToFile(path, sb.String()); err != nil {
+			return fmt.Errorf("erro ao escrever em GITHUB_OUTPUT: %v", err)
+		}
+	}
+
+	if path := os.Getenv("GITHUB_STEP_SUMMARY"); path != "" {
+		var sb strings.Builder
+		writeActionsSummary(&sb, result)
+		if err := appendToFile(path, sb.String()); err != nil {
+			return fmt.Errorf("erro ao escrever em GITHUB_STEP_SUMMARY: %v", err)
+		}
+	}
+	return nil
+}
+
+// writeActionsOutputs escreve os outputs de uma linha de versão
+// (no monorepo, com o prefixo do pacote: services_api_version)
+func writeActionsOutputs(sb *strings.Builder, prefix string, target targetResult, dryRun bool) {
+	if target.Increment == "" {
+		return
+	}
+	fmt.Fprintf(sb, "%sversion=%s\n", prefix, target.NextVersion)
+	fmt.Fprintf(sb, "%sprevious_version=%s\n", prefix, target.PreviousTag)
+	fmt.Fprintf(sb, "%sincrement=%s\n", prefix, strings.ToLower(target.Increment))
+	if prefix != "" {
+		fmt.Fprintf(sb, "%sreleased=%t\n", prefix, target.NextVersion != "" && !dryRun)
+	}
+}
+
+// writeActionsSummary escreve o resumo do job em Markdown: a versão de cada
+// linha e a tabela dos commits analisados
+func writeActionsSummary(sb *strings.Builder, result releaseResult) {
+	sb.WriteString("## Go Release Manager\n\n")
+	if result.DryRun {
+		sb.WriteString("> Simulação (dry-run): nenhuma tag foi criada.\n\n")
+	}
+	targets := result.Packages
+	if result.Increment != "" {
+		targets = append([]targetResult{result.targetResult}, targets...)
+	}
+	for _, target := range targets {
+		if target.Package != "" {
+			fmt.Fprintf(sb, "### Pacote `%s`\n\n", target.Package)
+		}
+		if target.NextVersion == "" {
+			fmt.Fprintf(sb, "Nenhum release: nenhuma mudança relevante desde `%s`.\n\n", target.PreviousTag)
+		} else {
+			fmt.Fprintf(sb, "**%s** → **%s** (%s)", target.PreviousTag, target.NextVersion, target.Increment)
+			if target.ReleaseURL != "" {
+				fmt.Fprintf(sb, " · [release](%s)", target.ReleaseURL)
+			}
+			sb.WriteString("\n\n")
+		}
+		if len(target.Commits) == 0 {
+			continue
+		}
+		sb.WriteString("| Commit | Tipo | Escopo | Mensagem | Incremento |\n")
+		sb.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, c := range target.Commits {
+			commitType := c.Type
+			if !c.Conventional {
+				commitType = "_não convencional_"
+			} else if c.Breaking {
+				commitType += " ⚠"
+			}
+			fmt.Fprintf(sb, "| `%.7s` | %s | %s | %s | %s |\n",
+				c.Hash, commitType, c.Scope, markdownCell(c.Subject), c.Increment)
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// markdownCell escapa o texto para uma célula de tabela Markdown
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+// appendToFile adiciona o conteúdo ao fim do arquivo (os arquivos do
+// Actions são compartilhados por todos os passos do job)
+func appendToFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(content)
+	return err
+}
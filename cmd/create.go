@@ -1,12 +1,10 @@
 package cmd
 
 import (
-	"fmt"
+	"errors"
 	"log"
+	"os"
 
-	// "os" // <-- REMOVIDO (movido para o pacote auth)
-
-	"go-release-manager/internal/auth"   // <-- NOVO PACOTE IMPORTADO
 	"go-release-manager/internal/config" // Importação existente
 	"go-release-manager/internal/git"
 	"go-release-manager/internal/semver"
@@ -19,13 +17,21 @@ var (
 	// token string // <-- Já removido
 	dryRun            bool
 	preReleaseChannel string
+	notesFile         string
+	createRelease     bool
+	tagViaAPI         bool
+	assets            []string
+	rewriteModulePath bool
+	outputFormat      string
+	failOnShallow     bool
 )
 
 var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: color.CyanString("Cria e empurra uma nova tag semântica."),
 	Long: color.WhiteString(`Analisa os commits desde a última tag, determina a próxima versão semântica,
-cria e empurra a tag. O release do GitHub (com os binários) será criado automaticamente pela GitHub Action.`),
+cria e empurra a tag. O release do GitHub (com os binários) será criado automaticamente pela GitHub Action,
+ou diretamente por esta ferramenta com a flag --release.`),
 
 	// --- EXEMPLO ATUALIZADO (com nova autenticação) ---
 	Example: color.YellowString(`
@@ -41,90 +47,136 @@ cria e empurra a tag. O release do GitHub (com os binários) será criado automa
   # Simula uma pré-release
   # (Autenticação é automática via GITHUB_TOKEN ou 'gh auth login')
   go-release-manager create -d -p rc
+
+  # Salva as notas de release geradas para uso no GoReleaser
+  go-release-manager create --notes-file RELEASE_NOTES.md
+
+  # Cria a tag e também o release no GitHub com as notas geradas
+  go-release-manager create --release
+
+  # GitLab: cria a tag e o release pela API (lê GITLAB_TOKEN ou token do 'glab')
+  go-release-manager create --release --tag-via-api
+
+  # Gitea/Forgejo: cria o release e anexa binários (lê GITEA_TOKEN)
+  go-release-manager create --release --asset dist/app.tar.gz --asset dist/checksums.txt
+
+  # Monorepo: com 'packages' no .go-releaserc.yml, cada pacote afetado
+  # recebe sua própria tag (ex: services/api/v1.5.0)
+  go-release-manager create -d
+
+  # Salto para v2+: atualiza o go.mod (sufixo /v2) e os imports antes da tag
+  go-release-manager create --rewrite-module-path
+
+  # Com 'branches' no .go-releaserc.yml, o canal vem do branch atual
+  # (ex: main -> estável, next -> beta)
+  go-release-manager create
+
+  # Branch de manutenção (ex: release/1.x com 'range: 1.x'): apenas
+  # correções e features da v1; um breaking change é recusado
+  go-release-manager create
+
+  # Resultado estruturado na saída padrão (logs vão para stderr)
+  VERSION=$(go-release-manager create -o json | jq -r .nextVersion)
+
+  # No GitHub Actions, os outputs version, previous_version, increment e
+  # released ficam disponíveis em steps.<id>.outputs
+  go-release-manager create
+
+  # Clone raso (fetch-depth: 1): aborta em vez de baixar o histórico
+  go-release-manager create --fail-on-shallow
 `),
 	// --- FIM DA ATUALIZAÇÃO ---
 
 	Run: func(cmd *cobra.Command, args []string) {
 
-		// --- LÓGICA DE AUTENTICAÇÃO (ATUALIZADA) ---
-		// Tenta GITHUB_TOKEN, e se falhar, tenta 'gh auth token'
-		// O token em si não é usado diretamente aqui, mas o 'gh' configura o git.
-		// A verificação é crucial para falhar rápido se nenhuma auth estiver disponível.
-		_, err := auth.GetToken()
-		if err != nil {
-			log.Fatalf("%s", color.RedString("Erro: Token de acesso não fornecido.\nDefina-o pela variável de ambiente GITHUB_TOKEN, ou faça login com o GitHub CLI (`gh auth login`).\nErro original: %v", err))
-		}
-		// --- FIM DA LÓGICA DE AUTENTICAÇÃO ---
-
 		// --- CARREGAR CONFIGURAÇÃO (Intacto) ---
 		cfg, err := config.LoadConfig()
 		if err != nil {
 			log.Fatalf(color.RedString("Erro ao carregar configuração .go-releaserc.yml: %v"), err)
 		}
+		if err := git.SetBackend(cfg.GitBackend); err != nil {
+			fatalGitError("Erro", err)
+		}
 		// --- FIM DO CARREGAMENTO ---
 
-		// 1. Obter a última tag (Intacto)
-		latestTag, err := git.GetLatestTag()
-		if err != nil {
-			log.Fatalf(color.RedString("Erro ao obter a última tag: %v"), err)
+		if err := validateOutputFormat(outputFormat); err != nil {
+			log.Fatalf(color.RedString("Erro: %v"), err)
 		}
-		log.Printf(color.GreenString("Última versão encontrada: %s"), latestTag)
-
-		// 2. Obter commits (Intacto)
-		commits, err := git.GetCommitsSince(latestTag)
-		if err != nil {
-			log.Fatalf(color.RedString("Erro ao obter commits: %v"), err)
+		if err := validateTagSigning(cfg); err != nil {
+			log.Fatalf(color.RedString("Erro: %v"), err)
 		}
-		log.Printf("Analisando %d commits desde a tag %s...", len(commits), latestTag)
 
-		// 3. DETERMINAR A PRÓXIMA VERSÃO (Intacto)
-		if preReleaseChannel != "" {
-			log.Printf(color.CyanString("Modo de pré-release ativado. Canal: %s"), preReleaseChannel)
-		}
+		session := newReleaseSession(cfg)
 
-		nextVersion, increment, err := semver.DetermineNextVersion(cfg, latestTag, commits, preReleaseChannel)
+		// O canal (e a faixa, em branches de manutenção) vem da flag -p ou da
+		// regra do branch atual em 'branches'
+		line, err := resolveBranch(cfg, preReleaseChannel)
 		if err != nil {
-			log.Fatalf(color.RedString("Erro ao determinar a próxima versão: %v"), err)
+			fatalGitError("Erro", err)
+		}
+		if err := ensureHistory(cfg, cfg.IncludePrereleases); err != nil {
+			fatalGitError("Erro", err)
 		}
 
-		if increment == semver.IncrementNone {
-			log.Println(color.YellowString("Nenhuma mudança relevante encontrada (feat, fix, BREAKING CHANGE, etc.). Nenhum release será criado."))
-			return
+		// 1. Calcular a próxima versão de cada linha de versão
+		// (o repositório inteiro ou, no modo monorepo, cada pacote)
+		if line.Channel != "" {
+			log.Printf(color.CyanString("Modo de pré-release ativado. Canal: %s"), line.Channel)
 		}
-		log.Printf(color.GreenString("Tipo de incremento: %s. Nova versão calculada: %s"), increment, nextVersion)
-
-		// 4. SE FOR --dry-run (INTACTO)
-		if dryRun {
-			fmt.Println(color.CyanString("\n--- MODO DRY RUN (SIMULAÇÃO) ---"))
-			fmt.Printf("Última tag encontrada: %s\n", latestTag)
-			if preReleaseChannel != "" {
-				fmt.Printf("Canal de pré-release: %s\n", preReleaseChannel)
+
+		analyzed := make([]*releasePlan, 0)
+		plans := make([]*releasePlan, 0)
+		for _, target := range releaseTargets(cfg) {
+			plan, err := planRelease(cfg, target, line)
+			if err != nil {
+				fatalGitError("Falha ao calcular o release", err)
+			}
+			analyzed = append(analyzed, plan)
+			if plan.Increment == semver.IncrementNone {
+				if target.Path != "" {
+					log.Printf(color.YellowString("Pacote %s: nenhuma mudança relevante. Nenhum release será criado para ele."), target.Path)
+				}
+				continue
 			}
-			fmt.Printf("Commits analisados: %d\n", len(commits))
-			fmt.Printf("Decisão de incremento: %s\n", color.MagentaString(increment.String()))
-			fmt.Printf("A nova tag a ser criada seria: %s\n", color.MagentaString(nextVersion))
-			fmt.Println(color.CyanString("--- FIM DO DRY RUN ---"))
-			return
+			plans = append(plans, plan)
 		}
 
-		// 5. Criar e empurrar a tag (INTACTA)
-		log.Printf("Criando tag git '%s'...", nextVersion)
-		if err := git.CreateTag(nextVersion); err != nil {
-			log.Fatalf(color.RedString("Erro ao criar tag: %v"), err)
+		if len(plans) == 0 {
+			log.Println(color.YellowString("Nenhuma mudança relevante encontrada (feat, fix, BREAKING CHANGE, etc.). Nenhum release será criado."))
+		} else {
+			session.run(plans)
 		}
 
-		log.Printf("Empurrando tag '%s' para o repositório remoto...", nextVersion)
-		if err := git.PushTag(nextVersion); err != nil {
-			log.Fatalf(color.RedString("Erro ao empurrar tag: %v"), err)
+		// Resultado estruturado para scripts de CI (--output) e, no GitHub
+		// Actions, para os próximos passos do workflow
+		result := newReleaseResult(analyzed)
+		if outputFormat != "" {
+			if err := writeResult(os.Stdout, outputFormat, result); err != nil {
+				log.Fatalf(color.RedString("Erro ao escrever o resultado: %v"), err)
+			}
+		}
+		if err := writeGitHubActions(result); err != nil {
+			log.Fatalf(color.RedString("Erro: %v"), err)
 		}
-
-		// --- LÓGICA RESTANTE (INTACTA) ---
-
-		log.Printf(color.GreenString("✅ Tag %s criada e empurrada com sucesso!"), nextVersion)
-		log.Println(color.CyanString("A GitHub Action 'Release' foi acionada. Verifique seu repositório em alguns minutos para os binários."))
 	},
 }
 
+// fatalGitError encerra o programa explicando como resolver as falhas
+// conhecidas do git, que são identificadas pelo tipo do erro e não pelo texto
+// (traduzido conforme o idioma da máquina)
+func fatalGitError(context string, err error) {
+	switch {
+	case errors.Is(err, git.ErrNotARepo):
+		log.Fatalf(color.RedString("%s: o diretório atual não é um repositório git. Execute o comando na raiz do projeto."), context)
+	case errors.Is(err, git.ErrDetachedHead):
+		log.Fatalf(color.RedString("%s: %v.\nFaça o checkout de um branch ou, em CI, defina GITHUB_REF_NAME (GitHub Actions) ou CI_COMMIT_BRANCH (GitLab CI)."), context, err)
+	case errors.Is(err, git.ErrShallowClone):
+		log.Fatalf(color.RedString("%s: %v.\nBaixe o histórico completo e as tags (ex: 'fetch-depth: 0' no actions/checkout ou 'git fetch --unshallow --tags')."), context, err)
+	default:
+		log.Fatalf(color.RedString("%s: %v"), context, err)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(createCmd)
 
@@ -143,6 +195,43 @@ func init() {
   # Simula uma pré-release
   # (Autenticação é automática via GITHUB_TOKEN ou 'gh auth login')
   go-release-manager create -d -p rc
+
+  # Salva as notas de release geradas para uso no GoReleaser
+  go-release-manager create --notes-file RELEASE_NOTES.md
+
+  # Cria a tag e também o release no GitHub com as notas geradas
+  go-release-manager create --release
+
+  # GitLab: cria a tag e o release pela API (lê GITLAB_TOKEN ou token do 'glab')
+  go-release-manager create --release --tag-via-api
+
+  # Gitea/Forgejo: cria o release e anexa binários (lê GITEA_TOKEN)
+  go-release-manager create --release --asset dist/app.tar.gz --asset dist/checksums.txt
+
+  # Monorepo: com 'packages' no .go-releaserc.yml, cada pacote afetado
+  # recebe sua própria tag (ex: services/api/v1.5.0)
+  go-release-manager create -d
+
+  # Salto para v2+: atualiza o go.mod (sufixo /v2) e os imports antes da tag
+  go-release-manager create --rewrite-module-path
+
+  # Com 'branches' no .go-releaserc.yml, o canal vem do branch atual
+  # (ex: main -> estável, next -> beta)
+  go-release-manager create
+
+  # Branch de manutenção (ex: release/1.x com 'range: 1.x'): apenas
+  # correções e features da v1; um breaking change é recusado
+  go-release-manager create
+
+  # Resultado estruturado na saída padrão (logs vão para stderr)
+  VERSION=$(go-release-manager create -o json | jq -r .nextVersion)
+
+  # No GitHub Actions, os outputs version, previous_version, increment e
+  # released ficam disponíveis em steps.<id>.outputs
+  go-release-manager create
+
+  # Clone raso (fetch-depth: 1): aborta em vez de baixar o histórico
+  go-release-manager create --fail-on-shallow
 `)
 	// --- FIM DA ATUALIZAÇÃO ---
 
@@ -154,4 +243,25 @@ func init() {
 
 	// Flag de Pré-Release (Intacta)
 	createCmd.Flags().StringVarP(&preReleaseChannel, "pre-release", "p", "", "Cria uma pré-release com o canal especificado (ex: beta, rc)")
+
+	// Flag de Release
+	createCmd.Flags().BoolVarP(&createRelease, "release", "r", false, "Cria também o release no provedor (GitHub, GitLab, Gitea) com as notas geradas (sem depender de uma GitHub Action)")
+
+	// Flag de Arquivos do Release
+	createCmd.Flags().StringSliceVar(&assets, "asset", nil, "Arquivo a anexar ao release criado com --release (pode ser repetida; suportado no Gitea/Forgejo)")
+
+	// Flag de Caminho do Módulo Go
+	createCmd.Flags().BoolVar(&rewriteModulePath, "rewrite-module-path", false, "Em um salto para v2+, atualiza o caminho do módulo no go.mod (sufixo /vN) e os imports internos antes de criar a tag")
+
+	// Flag de Tag via API
+	createCmd.Flags().BoolVar(&tagViaAPI, "tag-via-api", false, "Cria a tag pela API do provedor (GitLab) em vez de 'git tag' + 'git push'")
+
+	// Flag de Notas de Release
+	createCmd.Flags().StringVar(&notesFile, "notes-file", "", "Salva as notas de release (Markdown) no arquivo especificado (ex: para 'goreleaser --release-notes')")
+
+	// Flag de Saída Estruturada
+	createCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Escreve o resultado (tag anterior, nova versão, commits, release) na saída padrão em json, yaml ou env; os logs vão para stderr")
+
+	// Flag para clones rasos (CI com 'fetch-depth: 1')
+	createCmd.Flags().BoolVar(&failOnShallow, "fail-on-shallow", false, "Em um clone raso, aborta em vez de baixar as tags e o histórico necessários do remote 'origin'")
 }
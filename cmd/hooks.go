@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"go-release-manager/internal/git"
+	"go-release-manager/internal/hooks"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	installPrePush bool
+	uninstallHooks bool
+)
+
+var installHooksCmd = &cobra.Command{
+	Use:   "install-hooks",
+	Short: color.CyanString("Instala os hooks git que validam as mensagens de commit."),
+	Long: color.WhiteString(`Instala o hook commit-msg (e, opcionalmente, o pre-push), que valida as mensagens com
+'go-release-manager lint' e as regras do .go-releaserc.yml antes que os commits cheguem ao remoto.
+
+Os hooks são escritos em .git/hooks ou no diretório definido em core.hooksPath. Um hook que já
+existia é renomeado para <nome>.local e continua sendo executado antes do nosso.`),
+	Example: color.YellowString(`
+  # Instala o hook commit-msg
+  go-release-manager install-hooks
+
+  # Instala também o pre-push (valida os commits que serão empurrados)
+  go-release-manager install-hooks --pre-push
+
+  # Remove os hooks instalados e restaura os anteriores
+  go-release-manager install-hooks --uninstall
+`),
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := git.GetHooksDir()
+		if err != nil {
+			log.Fatalf(color.RedString("Erro ao localizar o diretório de hooks: %v"), err)
+		}
+
+		names := []string{hooks.CommitMsg}
+		if installPrePush || uninstallHooks {
+			names = append(names, hooks.PrePush)
+		}
+
+		if uninstallHooks {
+			for _, name := range names {
+				removed, err := hooks.Uninstall(dir, name)
+				if err != nil {
+					log.Fatalf(color.RedString("Erro ao remover o hook %s: %v"), name, err)
+				}
+				if removed {
+					log.Printf(color.GreenString("✅ Hook %s removido de %s."), name, dir)
+				}
+			}
+			return
+		}
+
+		binary, err := os.Executable()
+		if err != nil {
+			log.Fatalf(color.RedString("Erro ao localizar o executável: %v"), err)
+		}
+		if resolved, err := filepath.EvalSymlinks(binary); err == nil {
+			binary = resolved
+		}
+
+		for _, name := range names {
+			script, err := hooks.Script(name, binary)
+			if err != nil {
+				log.Fatalf(color.RedString("Erro: %v"), err)
+			}
+			chained, err := hooks.Install(dir, name, script)
+			if err != nil {
+				log.Fatalf(color.RedString("Erro ao instalar o hook %s: %v"), name, err)
+			}
+			if chained {
+				log.Printf(color.YellowString("O hook %s existente foi renomeado para %s.local e será executado antes da validação."), name, name)
+			}
+			log.Printf(color.GreenString("✅ Hook %s instalado em %s."), name, dir)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(installHooksCmd)
+
+	installHooksCmd.Flags().BoolVar(&installPrePush, "pre-push", false, "Instala também o hook pre-push, que valida os commits antes do push")
+	installHooksCmd.Flags().BoolVar(&uninstallHooks, "uninstall", false, "Remove os hooks instalados por esta ferramenta e restaura os anteriores")
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"go-release-manager/internal/config"
+	"go-release-manager/internal/git"
+	"go-release-manager/internal/lint"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var lintRange string
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [arquivo]",
+	Short: color.CyanString("Valida mensagens de commit no padrão Conventional Commits."),
+	Long: color.WhiteString(`Valida uma mensagem de commit (de um arquivo, da entrada padrão ou de um intervalo de
+commits) com o mesmo parser usado no cálculo da versão e as regras de 'lint' do .go-releaserc.yml
+(tipos, escopos, tamanho do cabeçalho e footers obrigatórios).
+Retorna um código de saída diferente de zero se alguma regra for violada.`),
+	Example: color.YellowString(`
+  # Valida a mensagem de um hook commit-msg (o git passa o arquivo em $1)
+  go-release-manager lint "$1"
+
+  # Valida uma mensagem pela entrada padrão
+  echo "feat(api): novo endpoint" | go-release-manager lint
+
+  # Valida todos os commits de um PR
+  go-release-manager lint --range origin/main..HEAD
+`),
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		// A saída do lint são as violações; os logs do config ficam de fora
+		log.SetOutput(io.Discard)
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			exitWithError("Erro ao carregar configuração .go-releaserc.yml: %v", err)
+		}
+		if err := git.SetBackend(cfg.GitBackend); err != nil {
+			exitWithError("Erro: %v", err)
+		}
+
+		failed := 0
+		if lintRange != "" {
+			commits, err := git.GetCommitsInRange(lintRange)
+			if err != nil {
+				exitWithError("Erro ao obter os commits de '%s': %v", lintRange, err)
+			}
+			for _, commit := range commits {
+				if commit.IsMerge || lint.Ignored(commit.Message) {
+					continue
+				}
+				if !reportViolations(commit.ShortHash, lint.Message(cfg, commit.Message)) {
+					failed++
+				}
+			}
+			if failed == 0 {
+				fmt.Println(color.GreenString("✅ %d commit(s) em '%s' seguem o padrão.", len(commits), lintRange))
+			}
+		} else {
+			message, err := readLintInput(args)
+			if err != nil {
+				exitWithError("Erro ao ler a mensagem de commit: %v", err)
+			}
+			message = lint.CleanMessage(message)
+			if lint.Ignored(message) {
+				return
+			}
+			if !reportViolations("", lint.Message(cfg, message)) {
+				failed++
+			}
+		}
+
+		if failed > 0 {
+			fmt.Fprintln(os.Stderr, color.YellowString("Formato esperado: tipo(escopo): descrição (ex: 'feat(api): adiciona endpoint de busca')"))
+			os.Exit(1)
+		}
+	},
+}
+
+// readLintInput lê a mensagem do arquivo informado ou, sem argumento (ou com
+// "-"), da entrada padrão
+func readLintInput(args []string) (string, error) {
+	if len(args) == 0 || args[0] == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		return string(data), err
+	}
+	data, err := os.ReadFile(args[0])
+	return string(data), err
+}
+
+// reportViolations imprime as violações de uma mensagem em stderr.
+// Retorna true se não houver nenhuma.
+func reportViolations(hash string, violations []lint.Violation) bool {
+	if len(violations) == 0 {
+		return true
+	}
+	prefix := ""
+	if hash != "" {
+		prefix = hash + ": "
+	}
+	for _, v := range violations {
+		fmt.Fprintln(os.Stderr, color.RedString("✖ %s%s", prefix, v))
+	}
+	return false
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().StringVar(&lintRange, "range", "", "Valida todos os commits de um intervalo do git (ex: origin/main..HEAD)")
+}
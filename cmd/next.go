@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"go-release-manager/internal/config"
+	"go-release-manager/internal/git"
+	"go-release-manager/internal/semver"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// exitNoRelease é o código de saída do 'next' quando nenhum release é
+// necessário (1 continua reservado para erros)
+const exitNoRelease = 3
+
+var (
+	nextChannel  string
+	nextPackage  string
+	nextNoPrefix bool
+	nextVerbose  bool
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: color.CyanString("Imprime apenas a próxima versão, sem criar tags ou releases."),
+	Long: color.WhiteString(`Calcula a próxima versão a partir da última tag e dos commits desde então e a imprime
+na saída padrão, sem nenhuma outra mensagem. Não cria tags, não faz commits e não precisa de token.
+
+Se nenhum release for necessário, nada é impresso e o código de saída é 3.`),
+	Example: color.YellowString(`
+  # Imprime a próxima tag (ex: v1.5.0)
+  go-release-manager next
+
+  # Próxima pré-release do canal beta (ex: v1.5.0-beta.1)
+  go-release-manager next --channel beta
+
+  # Apenas a versão semântica, para o ldflags do build
+  go build -ldflags "-X main.version=$(go-release-manager next --no-prefix)"
+
+  # Monorepo: próxima versão de um pacote
+  go-release-manager next --package services/api
+`),
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// A saída padrão deve conter apenas a versão; os logs só com --verbose
+		if !nextVerbose {
+			log.SetOutput(io.Discard)
+		}
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			exitWithError("Erro ao carregar configuração .go-releaserc.yml: %v", err)
+		}
+		if err := git.SetBackend(cfg.GitBackend); err != nil {
+			exitWithError("Erro: %v", err)
+		}
+		line, err := resolveBranch(cfg, nextChannel)
+		if err != nil {
+			exitWithError("Erro: %v", err)
+		}
+		if err := ensureHistory(cfg, cfg.IncludePrereleases); err != nil {
+			exitWithError("Erro: %v", err)
+		}
+
+		nextPackage = strings.Trim(nextPackage, "/")
+		found := false
+		released := false
+		for _, target := range releaseTargets(cfg) {
+			if nextPackage != "" && target.Path != nextPackage {
+				continue
+			}
+			found = true
+			plan, err := planRelease(cfg, target, line)
+			if err != nil {
+				exitWithError("Falha ao calcular a próxima versão: %v", err)
+			}
+			if plan.Increment == semver.IncrementNone {
+				continue
+			}
+			released = true
+			if nextNoPrefix {
+				version, _ := target.TagFormat.Version(plan.NextVersion)
+				fmt.Println(version)
+			} else {
+				fmt.Println(plan.NextVersion)
+			}
+		}
+
+		if !found {
+			exitWithError("Erro: o pacote '%s' não está configurado em 'packages'", nextPackage)
+		}
+		if !released {
+			os.Exit(exitNoRelease)
+		}
+	},
+}
+
+// exitWithError imprime o erro em stderr (mesmo com os logs desligados) e
+// encerra com o código 1
+func exitWithError(format string, args ...interface{}) {
+	fmt.Fprintln(os.Stderr, color.RedString(format, args...))
+	os.Exit(1)
+}
+
+func init() {
+	rootCmd.AddCommand(nextCmd)
+
+	nextCmd.Flags().StringVarP(&nextChannel, "channel", "c", "", "Calcula a próxima pré-release do canal especificado (ex: beta, rc)")
+	nextCmd.Flags().StringVar(&nextPackage, "package", "", "Monorepo: calcula apenas a versão do pacote informado (ex: services/api)")
+	nextCmd.Flags().BoolVar(&nextNoPrefix, "no-prefix", false, "Imprime apenas a versão semântica (1.5.0 em vez de v1.5.0)")
+	nextCmd.Flags().BoolVarP(&nextVerbose, "verbose", "v", false, "Mostra os logs da análise em stderr")
+	nextCmd.Flags().BoolVar(&failOnShallow, "fail-on-shallow", false, "Em um clone raso, aborta em vez de baixar as tags e o histórico necessários do remote 'origin'")
+}
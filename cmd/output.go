@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go-release-manager/internal/semver"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formatos aceitos por --output
+const (
+	outputJSON = "json"
+	outputYAML = "yaml"
+	outputEnv  = "env"
+)
+
+// console é onde as mensagens para humanos (ex: o bloco do dry-run) são
+// escritas. Com --output, a saída padrão fica reservada para o resultado
+// estruturado e tudo o mais vai para stderr, junto com os logs.
+func console() io.Writer {
+	if outputFormat != "" {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// validateOutputFormat verifica o valor da flag --output
+func validateOutputFormat(format string) error {
+	switch format {
+	case "", outputJSON, outputYAML, outputEnv:
+		return nil
+	default:
+		return fmt.Errorf("formato de saída inválido '%s' (use json, yaml ou env)", format)
+	}
+}
+
+// releaseResult é o resultado estruturado de 'create --output'. No modo
+// monorepo, os campos da versão ficam em 'packages', um item por pacote.
+type releaseResult struct {
+	Released     bool `json:"released" yaml:"released"`
+	DryRun       bool `json:"dryRun" yaml:"dryRun"`
+	targetResult `yaml:",inline"`
+	Packages     []targetResult `json:"packages,omitempty" yaml:"packages,omitempty"`
+}
+
+// targetResult é o resultado de uma linha de versão
+type targetResult struct {
+	Package     string         `json:"package,omitempty" yaml:"package,omitempty"`
+	PreviousTag string         `json:"previousTag,omitempty" yaml:"previousTag,omitempty"`
+	NextVersion string         `json:"nextVersion,omitempty" yaml:"nextVersion,omitempty"`
+	Increment   string         `json:"increment,omitempty" yaml:"increment,omitempty"`
+	Channel     string         `json:"channel,omitempty" yaml:"channel,omitempty"`
+	Commits     []commitResult `json:"commits,omitempty" yaml:"commits,omitempty"`
+	TagPushed   bool           `json:"tagPushed" yaml:"tagPushed"`
+	ReleaseURL  string         `json:"releaseUrl,omitempty" yaml:"releaseUrl,omitempty"`
+}
+
+// commitResult é um commit analisado e sua classificação
+type commitResult struct {
+	Hash         string `json:"hash" yaml:"hash"`
+	Subject      string `json:"subject" yaml:"subject"`
+	Conventional bool   `json:"conventional" yaml:"conventional"`
+	Type         string `json:"type,omitempty" yaml:"type,omitempty"`
+	Scope        string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Breaking     bool   `json:"breaking" yaml:"breaking"`
+	Increment    string `json:"increment" yaml:"increment"`
+}
+
+// newReleaseResult monta o resultado a partir dos planos analisados
+// (inclusive os que não geraram release)
+func newReleaseResult(plans []*releasePlan) releaseResult {
+	result := releaseResult{DryRun: dryRun}
+	for _, plan := range plans {
+		target := newTargetResult(plan)
+		if plan.Increment != semver.IncrementNone {
+			result.Released = true
+		}
+		if plan.Target.Path == "" {
+			result.targetResult = target
+			continue
+		}
+		result.Packages = append(result.Packages, target)
+	}
+	return result
+}
+
+// newTargetResult classifica os commits de um plano: os convencionais com o
+// tipo e o incremento de AnalyzeCommits, os demais como não convencionais
+func newTargetResult(plan *releasePlan) targetResult {
+	target := targetResult{
+		Package:     plan.Target.Path,
+		PreviousTag: plan.LatestTag,
+		Increment:   plan.Increment.String(),
+		Channel:     plan.Channel,
+		TagPushed:   plan.TagPushed,
+		ReleaseURL:  plan.ReleaseURL,
+	}
+	if plan.Increment != semver.IncrementNone {
+		target.NextVersion = plan.NextVersion
+	}
+
+	changes := make(map[string]semver.Change, len(plan.Changes))
+	for _, change := range plan.Changes {
+		changes[change.Commit.Hash] = change
+	}
+	for _, commit := range plan.Commits {
+		c := commitResult{Hash: commit.Hash, Subject: commit.Subject, Increment: semver.IncrementNone.String()}
+		if change, ok := changes[commit.Hash]; ok {
+			c.Conventional = true
+			c.Type = change.Type
+			c.Scope = change.Scope
+			c.Breaking = change.Breaking
+			c.Increment = change.Increment.String()
+		}
+		target.Commits = append(target.Commits, c)
+	}
+	return target
+}
+
+// writeResult escreve o resultado na saída padrão no formato de --output
+func writeResult(w io.Writer, format string, result releaseResult) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case outputYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		defer enc.Close()
+		return enc.Encode(result)
+	case outputEnv:
+		writeEnv(w, "", result.targetResult)
+		fmt.Fprintf(w, "RELEASED=%t\n", result.Released)
+		fmt.Fprintf(w, "DRY_RUN=%t\n", result.DryRun)
+		for _, pkg := range result.Packages {
+			writeEnv(w, envPrefix(pkg.Package), pkg)
+		}
+		return nil
+	}
+	return nil
+}
+
+// writeEnv escreve uma linha de versão como variáveis KEY=valor. A lista de
+// commits vira apenas a contagem.
+func writeEnv(w io.Writer, prefix string, target targetResult) {
+	if target.PreviousTag == "" && target.Increment == "" {
+		return
+	}
+	vars := []struct{ key, value string }{
+		{"PREVIOUS_TAG", target.PreviousTag},
+		{"NEXT_VERSION", target.NextVersion},
+		{"INCREMENT", target.Increment},
+		{"CHANNEL", target.Channel},
+		{"COMMITS", strconv.Itoa(len(target.Commits))},
+		{"TAG_PUSHED", strconv.FormatBool(target.TagPushed)},
+		{"RELEASE_URL", target.ReleaseURL},
+	}
+	for _, v := range vars {
+		fmt.Fprintf(w, "%s%s=%s\n", prefix, v.key, v.value)
+	}
+}
+
+var nonAlnumRegex = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// envPrefix converte o caminho de um pacote em prefixo de variável
+// (ex: "services/api" -> "SERVICES_API_")
+func envPrefix(path string) string {
+	return strings.ToUpper(nonAlnumRegex.ReplaceAllString(path, "_")) + "_"
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go-release-manager/internal/changelog"
+	"go-release-manager/internal/config"
+	"go-release-manager/internal/git"
+	"go-release-manager/internal/semver"
+
+	mastersemver "github.com/Masterminds/semver/v3"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var promoteCmd = &cobra.Command{
+	Use:   "promote",
+	Short: color.CyanString("Promove a última pré-release (ex: -rc.N) para a versão estável."),
+	Long: color.WhiteString(`Encontra a pré-release mais recente acima da última versão estável (ex: v1.3.0-rc.4),
+verifica que HEAD é essa pré-release ou descende dela, e cria a tag estável (v1.3.0)
+sem recalcular o incremento.`),
+	Example: color.YellowString(`
+  # Promove v1.3.0-rc.4 para v1.3.0
+  go-release-manager promote
+
+  # Simula a promoção (dry-run)
+  go-release-manager promote -d
+
+  # Promove e cria o release no provedor com as notas desde a última versão estável
+  go-release-manager promote --release
+`),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			log.Fatalf(color.RedString("Erro ao carregar configuração .go-releaserc.yml: %v"), err)
+		}
+		if err := git.SetBackend(cfg.GitBackend); err != nil {
+			log.Fatalf(color.RedString("Erro: %v"), err)
+		}
+		if err := validateTagSigning(cfg); err != nil {
+			log.Fatalf(color.RedString("Erro: %v"), err)
+		}
+
+		// Uma versão estável só pode sair de um branch estável
+		line, err := resolveBranch(cfg, "")
+		if err != nil {
+			log.Fatalf(color.RedString("Erro: %v"), err)
+		}
+		if line.Channel != "" {
+			log.Fatalf(color.RedString("Erro: o branch atual publica pré-releases (canal '%s'); 'promote' só pode ser executado em um branch estável."), line.Channel)
+		}
+		// A promoção parte da última versão estável
+		if err := ensureHistory(cfg, false); err != nil {
+			fatalGitError("Erro", err)
+		}
+
+		session := newReleaseSession(cfg)
+
+		plans := make([]*releasePlan, 0)
+		for _, target := range releaseTargets(cfg) {
+			plan, err := planPromotion(cfg, target, line)
+			if err != nil {
+				log.Fatalf(color.RedString("Falha ao preparar a promoção: %v"), err)
+			}
+			if plan != nil {
+				plans = append(plans, plan)
+			}
+		}
+
+		if len(plans) == 0 {
+			log.Println(color.YellowString("Nenhuma pré-release pendente acima da última versão estável. Nada a promover."))
+			return
+		}
+
+		session.run(plans)
+	},
+}
+
+// planPromotion monta o plano que transforma a pré-release mais recente de
+// uma linha de versão contida em HEAD em versão estável. Retorna nil se não houver
+// pré-release pendente. Em um branch de manutenção, apenas pré-releases da
+// faixa do branch são consideradas.
+func planPromotion(cfg *config.Config, target releaseTarget, line releaseLine) (*releasePlan, error) {
+	if target.Path != "" {
+		log.Printf(color.CyanString("--- Pacote %s (tags '%s') ---"), target.Path, target.TagFormat)
+	}
+
+	// 1. Última versão estável alcançável a partir de HEAD
+	stableTag, err := git.GetLatestTag(target.TagFormat, false)
+	if err != nil && !errors.Is(err, git.ErrNoTags) {
+		return nil, fmt.Errorf("erro ao obter a última tag: %v", err)
+	}
+	log.Printf(color.GreenString("Última versão estável: %s"), stableTag)
+
+	// 2. Pré-release mais recente acima dela contida em HEAD. Pré-releases de
+	// outros branches (ex: v2.0.0-beta.1 em 'next') não são promovidas aqui.
+	tags, err := git.ListVersionTags(target.TagFormat, false)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar as tags: %v", err)
+	}
+	var stable, preRelease *git.VersionTag
+	existing := make(map[string]bool)
+	for i, tag := range tags {
+		existing[tag.Name] = true
+		if tag.Name == stableTag {
+			stable = &tags[i]
+		}
+	}
+	merged, err := git.ListVersionTags(target.TagFormat, true)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar as tags: %v", err)
+	}
+	for i, tag := range merged {
+		if tag.Version.Prerelease() == "" || (stable != nil && !tag.Version.GreaterThan(stable.Version)) {
+			continue
+		}
+		if line.Range != nil && !line.Range.Contains(tag.Version) {
+			continue
+		}
+		if preRelease == nil || tag.Version.GreaterThan(preRelease.Version) {
+			preRelease = &merged[i]
+		}
+	}
+	if preRelease == nil {
+		return nil, nil
+	}
+	log.Printf(color.GreenString("Pré-release a promover: %s"), preRelease.Name)
+
+	// 3. A versão estável é a pré-release sem o sufixo, sem recalcular o incremento
+	nextVersion := target.TagFormat.Tag(semver.StableOf(preRelease.Version))
+	if existing[nextVersion] {
+		return nil, fmt.Errorf("a tag %s já existe", nextVersion)
+	}
+
+	// 4. Os commits feitos depois da pré-release não podem exigir uma versão
+	// maior que a promovida (ex: um breaking change depois de v1.3.0-rc.1)
+	paths := target.pathspecs()
+	sinceStable, err := git.GetCommitsSince(stableTag, paths...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter commits: %v", err)
+	}
+	required, _, err := semver.DetermineNextVersion(cfg, target.TagFormat, stableTag, semver.AnalyzeCommits(cfg, sinceStable), "", line.Range)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao determinar a próxima versão: %v", err)
+	}
+	promoted := mastersemver.MustParse(semver.StableOf(preRelease.Version))
+	if version, ok := target.TagFormat.Version(required); ok {
+		if v, err := mastersemver.NewVersion(version); err == nil && v.GreaterThan(promoted) {
+			return nil, fmt.Errorf("os commits feitos depois de %s exigem a versão %s, acima de %s. Crie uma nova pré-release com 'create -p' antes de promover", preRelease.Name, required, nextVersion)
+		}
+	}
+
+	// 5. A tag estável vai no commit da pré-release, que foi o que se testou.
+	// Com commits posteriores em HEAD, o commit de release (arquivos de versão,
+	// changelog, go.mod) não teria o mesmo conteúdo, então é recusado.
+	after, err := git.GetCommitsInRange(preRelease.Name + "..HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter commits: %v", err)
+	}
+	tagRef := ""
+	if len(after) > 0 {
+		if len(target.VersionFiles) > 0 || target.ChangelogFile != "" {
+			return nil, fmt.Errorf("HEAD tem %d commit(s) depois de %s e o release atualiza arquivos (versionFiles/changelogFile). Faça checkout da pré-release antes de promover", len(after), preRelease.Name)
+		}
+		tagRef = preRelease.Name
+	}
+
+	// 6. As notas cobrem tudo desde a última versão estável até a pré-release
+	commitRange := preRelease.Name
+	if git.TagExists(stableTag) {
+		commitRange = stableTag + ".." + preRelease.Name
+	}
+	commits, err := git.GetCommitsInRange(commitRange, paths...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter commits: %v", err)
+	}
+	changes := semver.AnalyzeCommits(cfg, commits)
+
+	plan := &releasePlan{
+		Target:       target,
+		LatestTag:    stableTag,
+		PromotedFrom: preRelease.Name,
+		TagRef:       tagRef,
+		Commits:      commits,
+		Changes:      changes,
+		NextVersion:  nextVersion,
+		Increment:    semver.HighestIncrement(changes),
+		Notes:        changelog.Generate(nextVersion, time.Now(), changes),
+	}
+	if err := plan.loadModulePath(); err != nil {
+		return nil, err
+	}
+	if tagRef != "" && rewriteModulePath && plan.checkModulePath() != nil {
+		return nil, fmt.Errorf("HEAD tem %d commit(s) depois de %s e --rewrite-module-path criaria um commit de release. Faça checkout da pré-release antes de promover", len(after), preRelease.Name)
+	}
+	return plan, nil
+}
+
+func init() {
+	rootCmd.AddCommand(promoteCmd)
+
+	promoteCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Simula a promoção sem criar tags ou releases")
+	promoteCmd.Flags().BoolVarP(&createRelease, "release", "r", false, "Cria também o release no provedor (GitHub, GitLab, Gitea) com as notas geradas")
+	promoteCmd.Flags().StringSliceVar(&assets, "asset", nil, "Arquivo a anexar ao release criado com --release (pode ser repetida; suportado no Gitea/Forgejo)")
+	promoteCmd.Flags().BoolVar(&tagViaAPI, "tag-via-api", false, "Cria a tag pela API do provedor (GitLab) em vez de 'git tag' + 'git push'")
+	promoteCmd.Flags().BoolVar(&rewriteModulePath, "rewrite-module-path", false, "Em um salto para v2+, atualiza o caminho do módulo no go.mod (sufixo /vN) e os imports internos antes de criar a tag")
+	promoteCmd.Flags().StringVar(&notesFile, "notes-file", "", "Salva as notas de release (Markdown) no arquivo especificado")
+	promoteCmd.Flags().BoolVar(&failOnShallow, "fail-on-shallow", false, "Em um clone raso, aborta em vez de baixar as tags e o histórico necessários do remote 'origin'")
+}
@@ -0,0 +1,649 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-release-manager/internal/auth"
+	"go-release-manager/internal/changelog"
+	"go-release-manager/internal/config"
+	"go-release-manager/internal/git"
+	"go-release-manager/internal/gomod"
+	"go-release-manager/internal/provider"
+	"go-release-manager/internal/semver"
+	"go-release-manager/internal/versionfile"
+
+	"github.com/fatih/color"
+)
+
+// releaseTarget é uma linha de versão independente: o repositório inteiro ou
+// um pacote de um monorepo.
+type releaseTarget struct {
+	// Path é o diretório do pacote. Vazio para o repositório inteiro.
+	Path string
+	// Nested são os outros pacotes dentro de Path (ex: todos, para o pacote
+	// da raiz), cujos commits não contam para este
+	Nested    []string
+	TagFormat git.TagFormat
+	// VersionFiles recebem a nova versão antes da tag e ChangelogFile a nova
+	// seção do changelog (caminhos já relativos à raiz do repositório)
+	VersionFiles  []config.VersionFile
+	ChangelogFile string
+}
+
+// releasePlan é o resultado da análise de uma linha de versão
+type releasePlan struct {
+	Target    releaseTarget
+	Channel   string // canal de pré-release (vazio para versões estáveis)
+	LatestTag string
+	// PromotedFrom é a pré-release promovida pelo comando 'promote' e TagRef
+	// o commit (ou tag) que recebe a nova tag; vazio = HEAD
+	PromotedFrom string
+	TagRef       string
+	Commits      []git.Commit
+	Changes      []semver.Change
+	NextVersion  string
+	Increment    semver.Increment
+	Notes        string
+	// ModulePath é o caminho atual do go.mod do pacote (vazio se não houver)
+	// e ExpectedModulePath o caminho exigido pela nova versão (sufixo /vN).
+	ModulePath         string
+	ExpectedModulePath string
+	// TagPushed e ReleaseURL registram o que foi publicado
+	TagPushed  bool
+	ReleaseURL string
+}
+
+// moduleDir retorna o diretório do pacote no working tree
+func (t releaseTarget) moduleDir() string {
+	if t.Path == "" {
+		return "."
+	}
+	return t.Path
+}
+
+// pathspecs retorna o filtro de caminhos dos commits do pacote: o seu
+// diretório, menos os pacotes aninhados. Nil para o repositório inteiro.
+func (t releaseTarget) pathspecs() []string {
+	if t.Path == "" {
+		return nil
+	}
+	paths := []string{t.Path}
+	for _, nested := range t.Nested {
+		paths = append(paths, git.ExcludePath(nested))
+	}
+	return paths
+}
+
+// checkModulePath retorna um erro explicativo quando a nova versão exige um
+// caminho de módulo diferente do declarado no go.mod.
+func (p *releasePlan) checkModulePath() error {
+	if p.ModulePath == p.ExpectedModulePath {
+		return nil
+	}
+	return fmt.Errorf("a versão %s exige o caminho de módulo '%s', mas o go.mod declara '%s'. "+
+		"Publicar a tag assim quebra o 'go get'. Use --rewrite-module-path para atualizar o go.mod e os imports antes de criar a tag",
+		p.NextVersion, p.ExpectedModulePath, p.ModulePath)
+}
+
+// releaseTargets retorna as linhas de versão do repositório: uma por pacote
+// no modo monorepo, ou apenas o repositório inteiro.
+func releaseTargets(cfg *config.Config) []releaseTarget {
+	if len(cfg.Packages) == 0 {
+		return []releaseTarget{{TagFormat: git.TagFormat(cfg.TagFormat), VersionFiles: cfg.VersionFiles, ChangelogFile: cfg.ChangelogFile}}
+	}
+	targets := make([]releaseTarget, 0, len(cfg.Packages))
+	for _, pkg := range cfg.Packages {
+		files := make([]config.VersionFile, 0, len(pkg.VersionFiles))
+		for _, file := range pkg.VersionFiles {
+			files = append(files, config.VersionFile{Path: filepath.Join(pkg.Path, file.Path), Pattern: file.Pattern})
+		}
+		target := releaseTarget{Path: pkg.Path, TagFormat: git.TagFormat(pkg.TagFormat), VersionFiles: files}
+		for _, other := range cfg.Packages {
+			if other.Path != pkg.Path && (pkg.Path == "." || strings.HasPrefix(other.Path, pkg.Path+"/")) {
+				target.Nested = append(target.Nested, other.Path)
+			}
+		}
+		if pkg.ChangelogFile != "" {
+			target.ChangelogFile = filepath.Join(pkg.Path, pkg.ChangelogFile)
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// releaseLine é o que a regra do branch atual permite lançar: o canal de
+// pré-release e, em branches de manutenção, a faixa de versões.
+type releaseLine struct {
+	Channel string // vazio para versões estáveis
+	Range   *semver.VersionRange
+}
+
+// resolveBranch aplica as regras de 'branches' do .go-releaserc.yml: recusa
+// branches não listados e retorna o canal e a faixa do branch atual. Um canal
+// passado explicitamente pela flag -p tem prioridade sobre o do branch.
+func resolveBranch(cfg *config.Config, flagChannel string) (releaseLine, error) {
+	if len(cfg.Branches) == 0 {
+		return releaseLine{Channel: flagChannel}, nil
+	}
+
+	branch, err := git.GetCurrentBranch()
+	if err != nil {
+		return releaseLine{}, fmt.Errorf("erro ao identificar o branch atual: %w", err)
+	}
+	rule, ok := cfg.MatchBranch(branch)
+	if !ok {
+		names := make([]string, 0, len(cfg.Branches))
+		for _, b := range cfg.Branches {
+			names = append(names, b.Name)
+		}
+		return releaseLine{}, fmt.Errorf("o branch '%s' não está configurado em 'branches'. Releases só podem ser criados a partir de: %s",
+			branch, strings.Join(names, ", "))
+	}
+
+	line := releaseLine{Channel: rule.Channel}
+	if rule.Range != "" {
+		line.Range, err = semver.ParseRange(rule.Range)
+		if err != nil {
+			return releaseLine{}, err
+		}
+		log.Printf("Branch '%s' (regra '%s'): linha de manutenção %s.", branch, rule.Name, line.Range)
+	}
+
+	if flagChannel != "" && flagChannel != rule.Channel {
+		log.Printf(color.YellowString("Aviso: o canal '%s' da flag -p substitui o canal do branch '%s'."), flagChannel, branch)
+		line.Channel = flagChannel
+		return line, nil
+	}
+	if line.Channel == "" {
+		log.Printf("Branch '%s' (regra '%s'): releases estáveis.", branch, rule.Name)
+	} else {
+		log.Printf("Branch '%s' (regra '%s'): canal de pré-release '%s'.", branch, rule.Name, line.Channel)
+	}
+	return line, nil
+}
+
+// ensureHistory prepara um clone raso (CI com 'fetch-depth: 1') antes do
+// cálculo das versões: baixa as tags e o histórico até a última tag de cada
+// linha de versão ou, com --fail-on-shallow, recusa o clone.
+// includePrereleases segue a busca da última tag feita pelo comando.
+func ensureHistory(cfg *config.Config, includePrereleases bool) error {
+	targets := releaseTargets(cfg)
+	formats := make([]git.TagFormat, 0, len(targets))
+	for _, target := range targets {
+		formats = append(formats, target.TagFormat)
+	}
+	fetched, err := git.EnsureHistory(formats, includePrereleases, !failOnShallow)
+	if err != nil {
+		return err
+	}
+	if fetched {
+		log.Println(color.YellowString("Clone raso (shallow) detectado: tags e histórico necessários baixados do remote 'origin'."))
+	}
+	return nil
+}
+
+// planRelease busca a última tag e os commits de uma linha de versão e
+// calcula a próxima versão e as notas de release.
+func planRelease(cfg *config.Config, target releaseTarget, line releaseLine) (*releasePlan, error) {
+	if target.Path != "" {
+		log.Printf(color.CyanString("--- Pacote %s (tags '%s') ---"), target.Path, target.TagFormat)
+	}
+
+	// 1. Obter a última tag
+	latestTag, err := git.GetLatestTag(target.TagFormat, cfg.IncludePrereleases)
+	switch {
+	case errors.Is(err, git.ErrNoTags):
+		log.Printf(color.YellowString("Nenhuma tag de versão encontrada. Começando a partir de %s."), latestTag)
+	case err != nil:
+		return nil, fmt.Errorf("erro ao obter a última tag: %w", err)
+	default:
+		log.Printf(color.GreenString("Última versão encontrada: %s"), latestTag)
+	}
+
+	// 2. Obter commits (no monorepo, apenas os que alteram arquivos do pacote)
+	commits, err := git.GetCommitsSince(latestTag, target.pathspecs()...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter commits: %w", err)
+	}
+	log.Printf("Analisando %d commits desde a tag %s...", len(commits), latestTag)
+
+	// 3. Determinar a próxima versão
+	changes := semver.AnalyzeCommits(cfg, commits)
+	nextVersion, increment, err := semver.DetermineNextVersion(cfg, target.TagFormat, latestTag, changes, line.Channel, line.Range)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao determinar a próxima versão: %v", err)
+	}
+
+	plan := &releasePlan{
+		Target:      target,
+		Channel:     line.Channel,
+		LatestTag:   latestTag,
+		Commits:     commits,
+		Changes:     changes,
+		NextVersion: nextVersion,
+		Increment:   increment,
+	}
+	if increment == semver.IncrementNone {
+		return plan, nil
+	}
+	log.Printf(color.GreenString("Tipo de incremento: %s. Nova versão calculada: %s"), increment, nextVersion)
+
+	// Gera as notas de release a partir das mesmas mudanças usadas no cálculo da versão
+	plan.Notes = changelog.Generate(nextVersion, time.Now(), changes)
+
+	// 4. Verificar o sufixo /vN do módulo Go, se houver um go.mod
+	if err := plan.loadModulePath(); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// loadModulePath lê o go.mod do pacote (se existir) e calcula o caminho de
+// módulo exigido pela nova versão
+func (p *releasePlan) loadModulePath() error {
+	modulePath, err := gomod.ModulePath(p.Target.moduleDir())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao ler o go.mod: %v", err)
+	}
+	version, _ := p.Target.TagFormat.Version(p.NextVersion)
+	expected, err := gomod.PathForVersion(modulePath, version)
+	if err != nil {
+		return err
+	}
+	p.ModulePath = modulePath
+	p.ExpectedModulePath = expected
+	return nil
+}
+
+// releaseSession guarda o provedor detectado e o token usados para publicar
+// os releases planejados
+type releaseSession struct {
+	cfg          *config.Config
+	remote       *git.Remote
+	providerType string
+	token        string
+}
+
+// newReleaseSession detecta o provedor e busca o token de autenticação,
+// encerrando o programa se nenhum token estiver disponível.
+func newReleaseSession(cfg *config.Config) *releaseSession {
+	// --- DETECÇÃO DO PROVEDOR ---
+	// O provedor (GitHub, GitLab, Gitea) vem do .go-releaserc.yml ou do host do remote 'origin'
+	remote, err := git.GetRemote()
+	if err != nil {
+		log.Printf(color.YellowString("Aviso: não foi possível analisar o remote 'origin': %v"), err)
+	}
+	providerType := provider.DetectType(cfg.Provider, remote)
+	host := ""
+	if remote != nil {
+		host = remote.Host
+	}
+	// --- FIM DA DETECÇÃO ---
+
+	// --- LÓGICA DE AUTENTICAÇÃO (ATUALIZADA) ---
+	// GitHub: tenta GITHUB_TOKEN, e se falhar, tenta 'gh auth token'
+	// GitLab: tenta GITLAB_TOKEN, e se falhar, tenta o 'glab'
+	// Gitea/Forgejo: tenta GITEA_TOKEN ou FORGEJO_TOKEN
+	// A verificação é crucial para falhar rápido se nenhuma auth estiver disponível.
+	token, err := auth.GetTokenFor(providerType, host)
+	if err != nil {
+		log.Fatalf("%s", color.RedString("Erro: Token de acesso não fornecido.\nDefina-o pela variável de ambiente GITHUB_TOKEN (ou GITLAB_TOKEN, GITEA_TOKEN), ou faça login com o GitHub CLI (`gh auth login`) ou GitLab CLI (`glab auth login`).\nErro original: %v", err))
+	}
+	// --- FIM DA LÓGICA DE AUTENTICAÇÃO ---
+	// O backend go-git não usa as credenciais do git; o push por HTTPS usa o token
+	git.SetCredentials("x-access-token", token)
+
+	return &releaseSession{cfg: cfg, remote: remote, providerType: providerType, token: token}
+}
+
+// run executa os planos: verifica o go.mod, simula (dry-run) ou cria as tags
+// e os releases.
+func (s *releaseSession) run(plans []*releasePlan) {
+	// 1. Verificar se o caminho do módulo Go comporta a nova versão (v2+)
+	if !dryRun && !rewriteModulePath {
+		for _, plan := range plans {
+			if err := plan.checkModulePath(); err != nil {
+				log.Fatalf(color.RedString("Erro: %v"), err)
+			}
+		}
+	}
+
+	// 2. SE FOR --dry-run
+	if dryRun {
+		out := console()
+		fmt.Fprintln(out, color.CyanString("\n--- MODO DRY RUN (SIMULAÇÃO) ---"))
+		for _, plan := range plans {
+			if plan.Target.Path != "" {
+				fmt.Fprintln(out, color.CyanString("\nPacote: %s", plan.Target.Path))
+			}
+			fmt.Fprintf(out, "Última tag encontrada: %s\n", plan.LatestTag)
+			if plan.Channel != "" {
+				fmt.Fprintf(out, "Canal de pré-release: %s\n", plan.Channel)
+			}
+			if plan.PromotedFrom != "" {
+				fmt.Fprintf(out, "Pré-release promovida: %s\n", plan.PromotedFrom)
+			}
+			if plan.TagRef != "" {
+				fmt.Fprintf(out, "A tag seria criada no commit de %s, não em HEAD\n", plan.TagRef)
+			}
+			fmt.Fprintf(out, "Commits analisados: %d\n", len(plan.Commits))
+			fmt.Fprintf(out, "Decisão de incremento: %s\n", color.MagentaString(plan.Increment.String()))
+			fmt.Fprintf(out, "A nova tag a ser criada seria: %s\n", color.MagentaString(plan.NextVersion))
+			if err := plan.checkModulePath(); err != nil {
+				if rewriteModulePath {
+					fmt.Fprintf(out, "O caminho do módulo seria reescrito: %s -> %s\n", plan.ModulePath, color.MagentaString(plan.ExpectedModulePath))
+				} else {
+					fmt.Fprintln(out, color.RedString("ERRO: %v", err))
+				}
+			}
+			if len(plan.Target.VersionFiles) > 0 {
+				paths := make([]string, 0, len(plan.Target.VersionFiles))
+				for _, file := range plan.Target.VersionFiles {
+					paths = append(paths, file.Path)
+				}
+				fmt.Fprintf(out, "Arquivos de versão que seriam atualizados: %s\n", strings.Join(paths, ", "))
+			}
+			if plan.Target.ChangelogFile != "" {
+				fmt.Fprintf(out, "Changelog que seria atualizado: %s\n", plan.Target.ChangelogFile)
+			}
+			if len(plan.Target.VersionFiles) > 0 || plan.Target.ChangelogFile != "" {
+				fmt.Fprintf(out, "Commit de release: %s\n", releaseCommitMessage(s.cfg, plan))
+			}
+			switch {
+			case s.cfg.Tag.Sign:
+				fmt.Fprintln(out, "A tag seria anotada e assinada, e a assinatura verificada antes do push")
+			case s.cfg.Tag.Annotated:
+				fmt.Fprintln(out, "A tag seria anotada, com as notas de release na mensagem")
+			}
+			if createRelease {
+				fmt.Fprintf(out, "Um release seria criado no %s (pré-release: %t)\n", s.providerType, plan.Channel != "")
+			}
+			fmt.Fprintln(out, color.CyanString("\n--- NOTAS DE RELEASE ---"))
+			fmt.Fprintln(out, plan.Notes)
+		}
+		fmt.Fprintln(out, color.CyanString("--- FIM DO DRY RUN ---"))
+		return
+	}
+
+	// Um commit que não pode ser criado precisa ser recusado antes de os
+	// arquivos de versão, o changelog e o go.mod serem alterados
+	if files, ok := s.releaseCommitFiles(plans); ok {
+		if err := git.CheckCommitFiles(files...); err != nil {
+			log.Fatalf(color.RedString("Erro ao preparar o commit de release: %v"), err)
+		}
+	}
+
+	// 3. Salvar as notas de release, se solicitado
+	if notesFile != "" {
+		var notes strings.Builder
+		for i, plan := range plans {
+			if i > 0 {
+				notes.WriteString("\n")
+			}
+			notes.WriteString(plan.Notes)
+		}
+		if err := os.WriteFile(notesFile, []byte(notes.String()), 0644); err != nil {
+			log.Fatalf(color.RedString("Erro ao salvar as notas de release em '%s': %v"), notesFile, err)
+		}
+		log.Printf("Notas de release salvas em '%s'.", notesFile)
+	}
+
+	// 4. Preparar o provedor, se ele for necessário (release ou tag via API)
+	ctx := context.Background()
+	var releaseProvider provider.Provider
+	if createRelease || tagViaAPI {
+		if s.remote == nil {
+			log.Fatalf("%s", color.RedString("Erro ao identificar o repositório remoto: remote 'origin' não encontrado ou inválido"))
+		}
+		var err error
+		releaseProvider, err = provider.New(ctx, s.providerType, s.cfg.Provider, s.remote, s.token)
+		if err != nil {
+			log.Fatalf(color.RedString("Erro ao configurar o provedor: %v"), err)
+		}
+	}
+
+	// 5. Criar as tags e os releases
+	for _, plan := range plans {
+		s.publish(ctx, plan, releaseProvider)
+	}
+
+	if !createRelease {
+		log.Println(color.CyanString("A GitHub Action 'Release' foi acionada. Verifique seu repositório em alguns minutos para os binários."))
+	}
+}
+
+// publish cria e empurra a tag de um plano e, se solicitado, cria o
+// release no provedor e anexa os arquivos.
+func (s *releaseSession) publish(ctx context.Context, plan *releasePlan, releaseProvider provider.Provider) {
+	nextVersion := plan.NextVersion
+
+	// 1. Reescrever o caminho do módulo Go (sufixo /vN), se necessário
+	if plan.checkModulePath() != nil {
+		log.Printf("Reescrevendo o caminho do módulo: %s -> %s...", plan.ModulePath, plan.ExpectedModulePath)
+		files, err := gomod.Rewrite(plan.Target.moduleDir(), plan.ModulePath, plan.ExpectedModulePath)
+		if err != nil {
+			log.Fatalf(color.RedString("Erro ao reescrever o caminho do módulo: %v"), err)
+		}
+		message := fmt.Sprintf("chore(release): move module to %s", plan.ExpectedModulePath)
+		if err := git.CommitFiles(message, files...); err != nil {
+			log.Fatalf(color.RedString("Erro ao criar o commit do novo caminho do módulo: %v"), err)
+		}
+		if err := git.PushHead(); err != nil {
+			log.Fatalf(color.RedString("Erro ao empurrar o commit do novo caminho do módulo: %v"), err)
+		}
+		log.Printf(color.GreenString("✅ %d arquivo(s) atualizado(s) para o caminho %s."), len(files), plan.ExpectedModulePath)
+	}
+
+	// 2. Gravar a nova versão e o changelog nos arquivos configurados e criar
+	// o commit de release
+	if files := s.updateReleaseFiles(plan); len(files) > 0 {
+		message := releaseCommitMessage(s.cfg, plan)
+		log.Printf("Criando o commit de release '%s' (%d arquivo(s))...", message, len(files))
+		if err := git.CommitFiles(message, files...); err != nil {
+			log.Fatalf(color.RedString("Erro ao criar o commit de release: %v"), err)
+		}
+		if err := git.PushHead(); err != nil {
+			log.Fatalf(color.RedString("Erro ao empurrar o commit de release: %v"), err)
+		}
+		log.Printf(color.GreenString("✅ Commit de release criado com: %s"), strings.Join(files, ", "))
+	}
+
+	// 3. Criar e empurrar a tag
+	if tagViaAPI {
+		tagCreator, ok := releaseProvider.(provider.TagCreator)
+		if !ok {
+			log.Fatalf(color.RedString("Erro: o provedor '%s' não suporta criar tags pela API"), s.providerType)
+		}
+		head := plan.TagRef
+		if head == "" {
+			var err error
+			head, err = git.GetHeadCommit()
+			if err != nil {
+				log.Fatalf(color.RedString("Erro ao obter o commit atual: %v"), err)
+			}
+		}
+		message := ""
+		if s.cfg.Tag.Annotated {
+			message = tagMessage(s.cfg, plan)
+		}
+		if plan.TagRef != "" {
+			log.Printf("Criando tag '%s' pela API do %s no commit de %s...", nextVersion, s.providerType, plan.TagRef)
+		} else {
+			log.Printf("Criando tag '%s' pela API do %s no commit %.7s...", nextVersion, s.providerType, head)
+		}
+		if err := tagCreator.CreateTag(ctx, nextVersion, head, message); err != nil {
+			log.Fatalf(color.RedString("Erro ao criar tag pela API: %v"), err)
+		}
+	} else {
+		opts := tagOptions(s.cfg, plan)
+		if plan.TagRef != "" {
+			log.Printf("Criando tag git '%s' no commit de %s...", nextVersion, plan.TagRef)
+		} else {
+			log.Printf("Criando tag git '%s'...", nextVersion)
+		}
+		if err := git.CreateTag(nextVersion, opts); err != nil {
+			log.Fatalf(color.RedString("Erro ao criar tag: %v"), err)
+		}
+
+		// A política de releases exige tags assinadas: uma tag que não passa
+		// na verificação não sai da máquina
+		if opts.Sign {
+			log.Printf("Verificando a assinatura da tag '%s'...", nextVersion)
+			if err := git.VerifyTag(nextVersion); err != nil {
+				log.Fatalf(color.RedString("Erro: a assinatura da tag %s não foi verificada e a tag não foi empurrada: %v\nNo formato ssh, configure gpg.ssh.allowedSignersFile. Remova a tag local com 'git tag -d %s' antes de tentar novamente."), nextVersion, err, nextVersion)
+			}
+			log.Printf(color.GreenString("✅ Assinatura da tag %s verificada."), nextVersion)
+		}
+
+		log.Printf("Empurrando tag '%s' para o repositório remoto...", nextVersion)
+		if err := git.PushTag(nextVersion); err != nil {
+			log.Fatalf(color.RedString("Erro ao empurrar tag: %v"), err)
+		}
+	}
+
+	plan.TagPushed = true
+	log.Printf(color.GreenString("✅ Tag %s criada e empurrada com sucesso!"), nextVersion)
+
+	// 4. Criar o release no provedor, se solicitado
+	if !createRelease {
+		return
+	}
+
+	log.Printf("Criando release '%s' em %s...", nextVersion, s.remote.Path())
+	releaseURL, err := releaseProvider.CreateRelease(ctx, nextVersion, plan.Notes, plan.Channel != "")
+	if err != nil {
+		log.Fatalf(color.RedString("Erro ao criar o release: %v"), err)
+	}
+	plan.ReleaseURL = releaseURL
+	log.Printf(color.GreenString("✅ Release criado com sucesso: %s"), releaseURL)
+
+	// 5. Anexar os arquivos ao release
+	if len(assets) == 0 {
+		return
+	}
+	uploader, ok := releaseProvider.(provider.AssetUploader)
+	if !ok {
+		log.Fatalf(color.RedString("Erro: o provedor '%s' não suporta upload de arquivos"), s.providerType)
+	}
+	for _, asset := range assets {
+		log.Printf("Enviando arquivo '%s' para o release...", asset)
+		if err := uploader.UploadAsset(ctx, nextVersion, asset); err != nil {
+			log.Fatalf(color.RedString("Erro ao enviar o arquivo '%s': %v"), asset, err)
+		}
+	}
+	log.Printf(color.GreenString("✅ %d arquivo(s) anexado(s) ao release."), len(assets))
+}
+
+// updateReleaseFiles grava a nova versão nos arquivos de versão e a nova seção
+// no changelog do repositório. Retorna os arquivos alterados.
+func (s *releaseSession) updateReleaseFiles(plan *releasePlan) []string {
+	version, _ := plan.Target.TagFormat.Version(plan.NextVersion)
+	files := make([]string, 0)
+
+	for _, file := range plan.Target.VersionFiles {
+		changed, err := versionfile.Update(file.Path, file.Pattern, version)
+		if err != nil {
+			log.Fatalf(color.RedString("Erro ao atualizar o arquivo de versão '%s': %v"), file.Path, err)
+		}
+		if changed {
+			log.Printf("Versão %s gravada em '%s'.", version, file.Path)
+			files = append(files, file.Path)
+		}
+	}
+
+	if plan.Target.ChangelogFile != "" {
+		link := ""
+		if s.remote != nil {
+			from := ""
+			if git.TagExists(plan.LatestTag) {
+				from = plan.LatestTag
+			}
+			link = provider.CompareURL(s.providerType, s.cfg.Provider, s.remote, from, plan.NextVersion)
+		}
+		section := changelog.GenerateFileSection(version, time.Now(), plan.Changes)
+		if err := changelog.PrependToFile(plan.Target.ChangelogFile, section, version, link); err != nil {
+			log.Fatalf(color.RedString("Erro ao atualizar o changelog '%s': %v"), plan.Target.ChangelogFile, err)
+		}
+		log.Printf("Seção %s adicionada a '%s'.", version, plan.Target.ChangelogFile)
+		files = append(files, plan.Target.ChangelogFile)
+	}
+	return files
+}
+
+// releaseCommitFiles retorna os arquivos de versão e changelogs que os planos
+// alteram e se algum plano cria um commit (inclusive o do novo caminho do
+// módulo, cujos arquivos só são conhecidos depois da reescrita)
+func (s *releaseSession) releaseCommitFiles(plans []*releasePlan) ([]string, bool) {
+	files := make([]string, 0)
+	commits := false
+	for _, plan := range plans {
+		for _, file := range plan.Target.VersionFiles {
+			files = append(files, file.Path)
+		}
+		if plan.Target.ChangelogFile != "" {
+			files = append(files, plan.Target.ChangelogFile)
+		}
+		if len(files) > 0 || (rewriteModulePath && plan.checkModulePath() != nil) {
+			commits = true
+		}
+	}
+	return files, commits
+}
+
+// releaseCommitMessage monta a mensagem do commit de release a partir de
+// 'releaseCommitMessage' (ex: "chore(release): v1.5.0")
+func releaseCommitMessage(cfg *config.Config, plan *releasePlan) string {
+	return strings.ReplaceAll(cfg.ReleaseCommitMessage, "${version}", plan.NextVersion)
+}
+
+// tagOptions monta as opções da tag a partir de 'tag' no .go-releaserc.yml
+func tagOptions(cfg *config.Config, plan *releasePlan) git.TagOptions {
+	opts := git.TagOptions{
+		Annotated:     cfg.Tag.Annotated,
+		Sign:          cfg.Tag.Sign,
+		SigningFormat: cfg.Tag.SigningFormat,
+		SigningKey:    cfg.Tag.SigningKey,
+		Ref:           plan.TagRef,
+	}
+	if opts.Annotated {
+		opts.Message = tagMessage(cfg, plan)
+	}
+	return opts
+}
+
+// tagMessage retorna a mensagem da tag anotada; ${version} é substituído pela
+// nova tag e ${notes} pelas notas de release
+func tagMessage(cfg *config.Config, plan *releasePlan) string {
+	replacer := strings.NewReplacer("${version}", plan.NextVersion, "${notes}", strings.TrimSpace(plan.Notes))
+	message := strings.TrimSpace(replacer.Replace(cfg.Tag.Message))
+	if message == "" {
+		return plan.NextVersion
+	}
+	return message
+}
+
+// validateTagSigning recusa, antes de qualquer commit ou push, as combinações
+// que não conseguem criar uma tag assinada
+func validateTagSigning(cfg *config.Config) error {
+	if !cfg.Tag.Sign {
+		return nil
+	}
+	if tagViaAPI {
+		return fmt.Errorf("tags criadas pela API (--tag-via-api) não podem ser assinadas; remova 'tag.sign' ou crie a tag com 'git tag'")
+	}
+	if cfg.GitBackend == git.BackendGoGit {
+		return fmt.Errorf("o backend go-git não assina tags; use 'gitBackend: exec' com 'tag.sign'")
+	}
+	return nil
+}
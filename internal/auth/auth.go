@@ -24,32 +24,95 @@ func GetToken() (string, error) {
 
 	// 2. Prioridade 2: GitHub CLI (Uso Local)
 	log.Println("GITHUB_TOKEN não definido. Tentando obter token do GitHub CLI (gh auth token)...")
+	return tokenFromCLI("gh", "auth", "token")
+}
+
+// GetGitHubEnterpriseToken busca um token para um GitHub Enterprise Server.
+// Prioriza as variáveis GH_ENTERPRISE_TOKEN e GITHUB_ENTERPRISE_TOKEN (as mesmas
+// do GitHub CLI), depois GITHUB_TOKEN e, por fim, 'gh auth token --hostname'.
+func GetGitHubEnterpriseToken(host string) (string, error) {
+	for _, name := range []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN", "GITHUB_TOKEN"} {
+		if token := os.Getenv(name); token != "" {
+			log.Printf("Token de autenticação encontrado via variável de ambiente %s.", name)
+			return token, nil
+		}
+	}
+
+	log.Printf("Nenhum token definido para %s. Tentando obter token do GitHub CLI (gh auth token)...", host)
+	return tokenFromCLI("gh", "auth", "token", "--hostname", host)
+}
+
+// GetGitLabToken busca um token de autenticação do GitLab.
+// Prioriza a variável de ambiente GITLAB_TOKEN.
+// Se não definida, tenta obter do GitLab CLI (glab config get token).
+func GetGitLabToken(host string) (string, error) {
+	token := os.Getenv("GITLAB_TOKEN")
+	if token != "" {
+		log.Println("Token de autenticação encontrado via variável de ambiente GITLAB_TOKEN.")
+		return token, nil
+	}
+
+	log.Println("GITLAB_TOKEN não definido. Tentando obter token do GitLab CLI (glab)...")
+	return tokenFromCLI("glab", "config", "get", "token", "--host", host)
+}
+
+// GetGiteaToken busca um token de autenticação do Gitea/Forgejo nas
+// variáveis de ambiente GITEA_TOKEN ou FORGEJO_TOKEN.
+func GetGiteaToken() (string, error) {
+	for _, name := range []string{"GITEA_TOKEN", "FORGEJO_TOKEN"} {
+		if token := os.Getenv(name); token != "" {
+			log.Printf("Token de autenticação encontrado via variável de ambiente %s.", name)
+			return token, nil
+		}
+	}
+	return "", fmt.Errorf("nenhuma das variáveis GITEA_TOKEN ou FORGEJO_TOKEN está definida")
+}
+
+// GetTokenFor busca o token do provedor informado ("github", "gitlab", "gitea").
+func GetTokenFor(providerType, host string) (string, error) {
+	switch providerType {
+	case "gitlab":
+		return GetGitLabToken(host)
+	case "gitea":
+		return GetGiteaToken()
+	default:
+		if host != "" && host != "github.com" {
+			return GetGitHubEnterpriseToken(host)
+		}
+		return GetToken()
+	}
+}
+
+// tokenFromCLI executa a CLI de um provedor (ex: 'gh', 'glab') e retorna o
+// token impresso na saída padrão.
+func tokenFromCLI(name string, args ...string) (string, error) {
+	commandLine := name + " " + strings.Join(args, " ")
 
-	// Verifica se 'gh' está instalado no PATH
-	path, err := exec.LookPath("gh")
+	// Verifica se a CLI está instalada no PATH
+	path, err := exec.LookPath(name)
 	if err != nil {
-		// 'gh' não está instalado.
-		return "", fmt.Errorf("'gh' CLI não encontrado no PATH")
+		// A CLI não está instalada.
+		return "", fmt.Errorf("'%s' CLI não encontrado no PATH", name)
 	}
 
-	// 'gh' está instalado, tente obter o token
-	cmd := exec.Command(path, "auth", "token")
+	// A CLI está instalada, tente obter o token
+	cmd := exec.Command(path, args...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
 		// O comando falhou (provavelmente o usuário não está logado)
-		errMsg := fmt.Sprintf("comando 'gh auth token' falhou: %s", stderr.String())
+		errMsg := fmt.Sprintf("comando '%s' falhou: %s", commandLine, stderr.String())
 		log.Printf("%s", color.RedString(errMsg))
 		return "", fmt.Errorf("%s", errMsg)
 	}
 
-	token = strings.TrimSpace(stdout.String())
+	token := strings.TrimSpace(stdout.String())
 	if token == "" {
-		return "", fmt.Errorf("comando 'gh auth token' foi executado mas não retornou um token")
+		return "", fmt.Errorf("comando '%s' foi executado mas não retornou um token", commandLine)
 	}
 
-	log.Println("Token de autenticação obtido com sucesso via GitHub CLI.")
+	log.Printf("Token de autenticação obtido com sucesso via '%s'.", name)
 	return token, nil
 }
@@ -0,0 +1,87 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go-release-manager/internal/semver"
+)
+
+// section agrupa as mudanças de um ou mais tipos de commit sob um título
+type section struct {
+	Title string
+	Types []string
+}
+
+// sections define a ordem e os títulos das seções das notas de release.
+// Tipos que não aparecem aqui (ex: chore, ci, test) não entram no changelog,
+// a não ser que sejam breaking changes.
+var sections = []section{
+	{Title: "Features", Types: []string{"feat"}},
+	{Title: "Bug Fixes", Types: []string{"fix"}},
+	{Title: "Performance Improvements", Types: []string{"perf"}},
+	{Title: "Reverts", Types: []string{"revert"}},
+	{Title: "Code Refactoring", Types: []string{"refactor"}},
+	{Title: "Documentation", Types: []string{"docs"}},
+}
+
+const breakingTitle = "⚠ BREAKING CHANGES"
+
+// Generate renderiza as notas de release em Markdown para a versão
+// informada, agrupando as mudanças por tipo de commit convencional.
+func Generate(version string, date time.Time, changes []semver.Change) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s (%s)\n", version, date.Format("2006-01-02"))
+
+	// 1. Breaking changes sempre aparecem primeiro, independente do tipo
+	breaking := make([]semver.Change, 0)
+	byType := make(map[string][]semver.Change)
+	for _, change := range changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+			continue
+		}
+		byType[change.Type] = append(byType[change.Type], change)
+	}
+
+	if len(breaking) > 0 {
+		fmt.Fprintf(&sb, "\n### %s\n\n", breakingTitle)
+		for _, change := range breaking {
+			writeEntry(&sb, change)
+			if change.BreakingNote != "" && change.BreakingNote != change.Description {
+				fmt.Fprintf(&sb, "  %s\n", strings.ReplaceAll(change.BreakingNote, "\n", "\n  "))
+			}
+		}
+	}
+
+	// 2. Demais seções, na ordem definida
+	for _, s := range sections {
+		entries := make([]semver.Change, 0)
+		for _, t := range s.Types {
+			entries = append(entries, byType[t]...)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", s.Title)
+		for _, change := range entries {
+			writeEntry(&sb, change)
+		}
+	}
+
+	return sb.String()
+}
+
+// writeEntry escreve uma linha do changelog: "* **escopo:** descrição (hash)"
+func writeEntry(sb *strings.Builder, change semver.Change) {
+	sb.WriteString("* ")
+	if change.Scope != "" {
+		fmt.Fprintf(sb, "**%s:** ", change.Scope)
+	}
+	sb.WriteString(change.Description)
+	if hash := change.Commit.ShortHash; hash != "" {
+		fmt.Fprintf(sb, " (%s)", hash)
+	}
+	sb.WriteString("\n")
+}
@@ -0,0 +1,200 @@
+package changelog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go-release-manager/internal/semver"
+)
+
+// fileHeader é o cabeçalho de um CHANGELOG.md novo (formato Keep a Changelog)
+const fileHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+
+// fileSections mapeia os tipos de commit para as categorias do Keep a
+// Changelog. Breaking changes entram em "Changed", antes das demais.
+var fileSections = []section{
+	{Title: "Added", Types: []string{"feat"}},
+	{Title: "Changed", Types: []string{"perf", "refactor", "revert"}},
+	{Title: "Fixed", Types: []string{"fix"}},
+	{Title: "Security", Types: []string{"security"}},
+}
+
+// GenerateFileSection renderiza a seção de uma versão no formato Keep a
+// Changelog (ex: "## [1.5.0] - 2026-10-17" seguido de Added, Changed...).
+func GenerateFileSection(version string, date time.Time, changes []semver.Change) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## [%s] - %s\n", version, date.Format("2006-01-02"))
+
+	byType := make(map[string][]semver.Change)
+	breaking := make([]semver.Change, 0)
+	for _, change := range changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+			continue
+		}
+		byType[change.Type] = append(byType[change.Type], change)
+	}
+
+	for _, s := range fileSections {
+		entries := make([]semver.Change, 0)
+		if s.Title == "Changed" {
+			entries = append(entries, breaking...)
+		}
+		for _, t := range s.Types {
+			entries = append(entries, byType[t]...)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", s.Title)
+		for _, change := range entries {
+			writeFileEntry(&sb, change)
+		}
+	}
+	return sb.String()
+}
+
+// writeFileEntry escreve uma linha do CHANGELOG.md: "- **escopo:** descrição (hash)"
+func writeFileEntry(sb *strings.Builder, change semver.Change) {
+	sb.WriteString("- ")
+	if change.Breaking {
+		sb.WriteString("**BREAKING:** ")
+	}
+	if change.Scope != "" {
+		fmt.Fprintf(sb, "**%s:** ", change.Scope)
+	}
+	sb.WriteString(change.Description)
+	if hash := change.Commit.ShortHash; hash != "" {
+		fmt.Fprintf(sb, " (%s)", hash)
+	}
+	sb.WriteString("\n")
+	if change.BreakingNote != "" && change.BreakingNote != change.Description {
+		fmt.Fprintf(sb, "  %s\n", strings.ReplaceAll(change.BreakingNote, "\n", "\n  "))
+	}
+}
+
+// PrependToFile insere a seção de uma nova versão no topo do changelog
+// (abaixo do cabeçalho e de '## [Unreleased]'), preservando o conteúdo
+// existente. O link de comparação (ex: "[1.5.0]: https://...") é inserido
+// antes das referências já existentes. Se o arquivo não existir, ele é criado.
+// Se a versão já tiver uma seção ou um link (ex: um release repetido depois
+// de uma falha no push), eles são substituídos no lugar, sem duplicar.
+func PrependToFile(path, section, version, link string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	content := string(data)
+	if strings.TrimSpace(content) == "" {
+		content = fileHeader
+	}
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+
+	// 1. Seção: no lugar da seção já existente da versão ou antes da
+	// primeira versão já publicada
+	sectionAt := len(lines)
+	for i, line := range lines {
+		if strings.HasPrefix(line, "## ") && !isUnreleased(line) {
+			sectionAt = i
+			break
+		}
+	}
+	if start, end := findSection(lines, version); start >= 0 {
+		lines = append(lines[:start], lines[end:]...)
+		sectionAt = start
+	}
+	// Sem versões anteriores, a seção vai antes das referências de link
+	if sectionAt == len(lines) {
+		if i := firstLinkRef(lines); i >= 0 {
+			sectionAt = i
+		}
+	}
+	sectionLines := strings.Split(strings.TrimRight(section, "\n"), "\n")
+	sectionLines = append(sectionLines, "")
+	if sectionAt > 0 && lines[sectionAt-1] != "" {
+		sectionLines = append([]string{""}, sectionLines...)
+	}
+	lines = insertLines(lines, sectionAt, sectionLines...)
+
+	// 2. Link: antes das referências existentes, ou no fim do arquivo
+	if link != "" {
+		ref := fmt.Sprintf("[%s]: %s", version, link)
+		if i := findLinkRef(lines, version); i >= 0 {
+			lines[i] = ref
+		} else if i := firstLinkRef(lines); i >= 0 {
+			lines = insertLines(lines, i, ref)
+		} else {
+			if lines[len(lines)-1] != "" {
+				lines = append(lines, "")
+			}
+			lines = append(lines, ref)
+		}
+	}
+
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
+// isUnreleased informa se a linha é o título da seção "[Unreleased]"
+func isUnreleased(line string) bool {
+	return strings.HasPrefix(strings.ToLower(line), "## [unreleased]")
+}
+
+// firstLinkRef retorna a primeira referência de link de versão (ex:
+// "[1.4.0]: https://..."), ignorando a de "[Unreleased]", ou -1
+func firstLinkRef(lines []string) int {
+	for i, line := range lines {
+		if isLinkRef(line) && !strings.HasPrefix(strings.ToLower(line), "[unreleased]") {
+			return i
+		}
+	}
+	return -1
+}
+
+// isLinkRef informa se a linha é uma referência de link (ex: "[1.4.0]: https://...")
+func isLinkRef(line string) bool {
+	return strings.HasPrefix(line, "[") && strings.Contains(line, "]: ")
+}
+
+// findSection retorna o intervalo [start, end) das linhas da seção da
+// versão, do título "## [versão]" até a próxima seção ou as referências de
+// link, ou -1 se a versão não tiver seção
+func findSection(lines []string, version string) (int, int) {
+	heading := "## [" + version + "]"
+	for start, line := range lines {
+		if line != heading && !strings.HasPrefix(line, heading+" ") {
+			continue
+		}
+		end := start + 1
+		for end < len(lines) && !strings.HasPrefix(lines[end], "## ") && !isLinkRef(lines[end]) {
+			end++
+		}
+		return start, end
+	}
+	return -1, -1
+}
+
+// findLinkRef retorna a referência de link da versão ("[versão]: ..."), ou -1
+func findLinkRef(lines []string, version string) int {
+	for i, line := range lines {
+		if strings.HasPrefix(line, "["+version+"]: ") {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertLines insere as linhas na posição informada
+func insertLines(lines []string, at int, inserted ...string) []string {
+	out := make([]string, 0, len(lines)+len(inserted))
+	out = append(out, lines[:at]...)
+	out = append(out, inserted...)
+	return append(out, lines[at:]...)
+}
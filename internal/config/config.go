@@ -1,8 +1,14 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"go-release-manager/internal/git"
 
 	"gopkg.in/yaml.v3"
 )
@@ -10,6 +16,126 @@ import (
 // Config é a estrutura principal do arquivo .go-releaserc.yml
 type Config struct {
 	ReleaseRules []ReleaseRule `yaml:"releaseRules"`
+	Provider     Provider      `yaml:"provider"`
+	// TagFormat define o nome das tags; ${version} é substituído pela versão
+	// (ex: "v${version}", "release-${version}", "${version}")
+	TagFormat string `yaml:"tagFormat"`
+	// IncludePrereleases faz as tags de pré-release (ex: v1.3.0-rc.1) contarem
+	// como base para o cálculo da próxima versão. Padrão: apenas estáveis.
+	IncludePrereleases bool `yaml:"includePrereleases"`
+	// PreReleaseChannels define a ordem dos canais de pré-release
+	// (ex: [alpha, beta, rc]). Se vazio, qualquer canal é aceito.
+	PreReleaseChannels []string `yaml:"preReleaseChannels"`
+	// Branches restringe de quais branches é possível lançar versões e
+	// define o canal de cada um. Se vazio, qualquer branch é aceito.
+	Branches []Branch `yaml:"branches"`
+	// Packages ativa o modo monorepo: cada pacote tem sua própria linha de versão
+	Packages []Package `yaml:"packages"`
+	// VersionFiles são os arquivos que recebem a nova versão antes da tag
+	// (ex: version.go, package.json, Chart.yaml, VERSION)
+	VersionFiles []VersionFile `yaml:"versionFiles"`
+	// ChangelogFile é o changelog do repositório (ex: "CHANGELOG.md"), no
+	// formato Keep a Changelog. Vazio = não gravado.
+	ChangelogFile string `yaml:"changelogFile"`
+	// Lint define as regras do comando 'lint' para as mensagens de commit
+	Lint Lint `yaml:"lint"`
+	// ReleaseCommitMessage é a mensagem do commit que leva os arquivos
+	// atualizados; ${version} é substituído pela nova tag.
+	ReleaseCommitMessage string `yaml:"releaseCommitMessage"`
+	// GitBackend escolhe como o repositório é acessado: "exec" (padrão,
+	// binário 'git') ou "go-git" (Go puro, sem depender do binário)
+	GitBackend string `yaml:"gitBackend"`
+	// Tag define se as tags de versão são anotadas e assinadas
+	Tag Tag `yaml:"tag"`
+}
+
+// Tag são as opções das tags de versão criadas com 'git tag'
+type Tag struct {
+	// Annotated cria tags anotadas (com autor, data e mensagem) em vez de leves
+	Annotated bool `yaml:"annotated"`
+	// Message é a mensagem da tag anotada; ${version} é substituído pela nova
+	// tag e ${notes} pelas notas de release geradas
+	Message string `yaml:"message"`
+	// Sign assina a tag (implica uma tag anotada) e verifica a assinatura
+	// antes do push
+	Sign bool `yaml:"sign"`
+	// SigningFormat é o formato da assinatura: "openpgp" (GPG) ou "ssh".
+	// Vazio = o gpg.format do git.
+	SigningFormat string `yaml:"signingFormat"`
+	// SigningKey é a chave usada na assinatura (ID da chave GPG ou caminho da
+	// chave SSH). Vazio = o user.signingKey do git.
+	SigningKey string `yaml:"signingKey"`
+}
+
+// Lint são as regras de validação das mensagens de commit
+type Lint struct {
+	// Types são os tipos aceitos (ex: feat, fix). Padrão: os tipos de releaseRules.
+	Types []string `yaml:"types"`
+	// Scopes são os escopos aceitos. Se vazio, qualquer escopo é aceito.
+	Scopes []string `yaml:"scopes"`
+	// MaxHeaderLength é o tamanho máximo da primeira linha (0 = sem limite)
+	MaxHeaderLength int `yaml:"maxHeaderLength"`
+	// RequiredFooters são os footers obrigatórios (ex: "Signed-off-by")
+	RequiredFooters []string `yaml:"requiredFooters"`
+}
+
+// AllowedTypes retorna os tipos de commit aceitos pelo 'lint'
+func (c *Config) AllowedTypes() []string {
+	if len(c.Lint.Types) > 0 {
+		return c.Lint.Types
+	}
+	types := make([]string, 0, len(c.ReleaseRules))
+	for _, rule := range c.ReleaseRules {
+		types = append(types, rule.Type)
+	}
+	return types
+}
+
+// VersionFile é um arquivo onde a versão é gravada a cada release
+type VersionFile struct {
+	Path string `yaml:"path"` // ex: "internal/version/version.go"
+	// Pattern é uma expressão regular cujo primeiro grupo é substituído pela
+	// versão (ex: 'Version = "(.*)"'). Vazio = o arquivo inteiro é a versão.
+	Pattern string `yaml:"pattern"`
+}
+
+// Branch associa um branch (ou padrão, ex: "release/*") a um canal de release
+type Branch struct {
+	Name    string `yaml:"name"`    // ex: "main", "next", "release/*"
+	Channel string `yaml:"channel"` // canal de pré-release (ex: "beta"); vazio = estável
+	// Range limita as versões de um branch de manutenção (ex: "1.x", "1.4.x").
+	// Incrementos que saiam da faixa são recusados.
+	Range string `yaml:"range"`
+}
+
+// rangeRegex valida as faixas de manutenção ("1.x", "v1.x", "1.4.x")
+var rangeRegex = regexp.MustCompile(`^v?\d+\.(\d+\.)?x$`)
+
+// MatchBranch retorna a primeira regra de 'branches' que corresponde ao
+// branch informado
+func (c *Config) MatchBranch(name string) (*Branch, bool) {
+	for i, branch := range c.Branches {
+		if ok, _ := path.Match(branch.Name, name); ok {
+			return &c.Branches[i], true
+		}
+	}
+	return nil, false
+}
+
+// Package é um módulo de um monorepo com versionamento independente
+type Package struct {
+	Path string `yaml:"path"` // ex: "services/api" ("." = raiz do repositório)
+	// TagPrefix é o prefixo das tags (ex: "services/api/"). Padrão: path + "/",
+	// ou nenhum para a raiz. Um valor vazio explícito (tagPrefix: "") também
+	// remove o prefixo, por isso o ponteiro.
+	TagPrefix *string `yaml:"tagPrefix"`
+	// TagFormat substitui o tagPrefix quando o pacote usa outro esquema
+	// (ex: "api-${version}"). Padrão: tagPrefix + o tagFormat global.
+	TagFormat string `yaml:"tagFormat"`
+	// VersionFiles e ChangelogFile do pacote, com caminhos relativos ao
+	// diretório do pacote
+	VersionFiles  []VersionFile `yaml:"versionFiles"`
+	ChangelogFile string        `yaml:"changelogFile"`
 }
 
 // ReleaseRule define como um tipo de commit afeta a versão
@@ -18,10 +144,24 @@ type ReleaseRule struct {
 	Release string `yaml:"release"` // "major", "minor", "patch", "none"
 }
 
+// Provider define onde os releases são publicados.
+// Se 'type' não for informado, ele é detectado pelo host do remote 'origin'.
+type Provider struct {
+	Type string `yaml:"type"` // "github", "gitlab", "gitea" (ou "forgejo")
+	URL  string `yaml:"url"`  // URL base do servidor (ex: https://gitlab.empresa.com)
+	// UploadURL é usado apenas no GitHub Enterprise Server, quando os uploads
+	// ficam em um host diferente da API. Padrão: o mesmo de URL.
+	UploadURL string `yaml:"uploadUrl"`
+}
+
 // defaultConfig retorna a configuração padrão (o comportamento atual)
 // caso nenhum .go-releaserc.yml seja encontrado.
 func defaultConfig() *Config {
 	return &Config{
+		TagFormat:            "v${version}",
+		ReleaseCommitMessage: "chore(release): ${version}",
+		Tag:                  Tag{Message: "Release ${version}\n\n${notes}"},
+		Lint:                 Lint{MaxHeaderLength: 100},
 		ReleaseRules: []ReleaseRule{
 			{Type: "feat", Release: "minor"},
 			{Type: "fix", Release: "patch"},
@@ -67,5 +207,84 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if err := git.TagFormat(config.TagFormat).Validate(); err != nil {
+		return nil, err
+	}
+
+	for i, branch := range config.Branches {
+		if branch.Name == "" {
+			return nil, fmt.Errorf("branch #%d sem 'name' definido", i+1)
+		}
+		if _, err := path.Match(branch.Name, ""); err != nil {
+			return nil, fmt.Errorf("padrão de branch inválido '%s': %v", branch.Name, err)
+		}
+		if branch.Range != "" && !rangeRegex.MatchString(branch.Range) {
+			return nil, fmt.Errorf("branch %s: faixa inválida '%s' (use o formato '1.x' ou '1.4.x')", branch.Name, branch.Range)
+		}
+	}
+
+	// Pacotes sem tagPrefix usam o caminho como prefixo (convenção do Go para
+	// módulos aninhados: services/api/v1.4.0); o módulo da raiz ("."), nenhum.
+	// Sem tagFormat, o prefixo é aplicado ao tagFormat global.
+	for i, pkg := range config.Packages {
+		if pkg.Path == "" {
+			return nil, fmt.Errorf("pacote #%d sem 'path' definido", i+1)
+		}
+		pkgPath := path.Clean(strings.Trim(pkg.Path, "/"))
+		config.Packages[i].Path = pkgPath
+		if pkg.TagPrefix == nil {
+			prefix := pkgPath + "/"
+			if pkgPath == "." {
+				prefix = ""
+			}
+			config.Packages[i].TagPrefix = &prefix
+		}
+		if pkg.TagFormat == "" {
+			config.Packages[i].TagFormat = *config.Packages[i].TagPrefix + config.TagFormat
+		}
+		if err := git.TagFormat(config.Packages[i].TagFormat).Validate(); err != nil {
+			return nil, fmt.Errorf("pacote %s: %v", pkg.Path, err)
+		}
+		if err := validateVersionFiles(pkg.VersionFiles); err != nil {
+			return nil, fmt.Errorf("pacote %s: %v", pkg.Path, err)
+		}
+	}
+
+	if err := validateVersionFiles(config.VersionFiles); err != nil {
+		return nil, err
+	}
+
+	switch config.Tag.SigningFormat {
+	case "", "openpgp", "ssh":
+	case "gpg":
+		config.Tag.SigningFormat = "openpgp"
+	default:
+		return nil, fmt.Errorf("tag: signingFormat inválido '%s' (use 'openpgp' ou 'ssh')", config.Tag.SigningFormat)
+	}
+	if config.Tag.Sign {
+		config.Tag.Annotated = true
+	}
+
 	return config, nil
 }
+
+// validateVersionFiles verifica se cada arquivo de versão tem um caminho e um
+// padrão válido, com ao menos um grupo de captura
+func validateVersionFiles(files []VersionFile) error {
+	for i, file := range files {
+		if file.Path == "" {
+			return fmt.Errorf("versionFiles #%d sem 'path' definido", i+1)
+		}
+		if file.Pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(file.Pattern)
+		if err != nil {
+			return fmt.Errorf("versionFiles %s: padrão inválido: %v", file.Path, err)
+		}
+		if re.NumSubexp() == 0 {
+			return fmt.Errorf("versionFiles %s: o padrão '%s' precisa de um grupo de captura (ex: 'Version = \"(.*)\"')", file.Path, file.Pattern)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Commit representa um commit do intervalo analisado, com os metadados do git
+// e a mensagem já separada em subject, corpo e footers.
+type Commit struct {
+	Hash          string
+	ShortHash     string
+	Author        string
+	AuthorEmail   string
+	CommitterDate time.Time
+	Subject       string
+	Body          string
+	Footers       []Footer
+	Parents       []string
+	IsMerge       bool
+	// Message é a mensagem completa, como gravada no commit
+	Message string
+}
+
+// Footer é um trailer da mensagem de commit (ex: "BREAKING CHANGE: ...",
+// "Refs: #123", "Signed-off-by: ...").
+type Footer struct {
+	Key   string
+	Value string
+}
+
+// Footer retorna o valor do primeiro footer com a chave informada.
+func (c Commit) Footer(key string) (string, bool) {
+	for _, f := range c.Footers {
+		if f.Key == key {
+			return f.Value, true
+		}
+	}
+	return "", false
+}
+
+// BreakingChangeNote retorna o texto do footer 'BREAKING CHANGE' (ou
+// 'BREAKING-CHANGE'), se existir.
+func (c Commit) BreakingChangeNote() (string, bool) {
+	if note, ok := c.Footer("BREAKING CHANGE"); ok {
+		return note, true
+	}
+	return c.Footer("BREAKING-CHANGE")
+}
+
+// footerRegex reconhece o início de um footer no formato do Conventional
+// Commits: "Token: valor" ou "Token #valor".
+var footerRegex = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[\w-]+)(?:: | #)(.*)`)
+
+var paragraphRegex = regexp.MustCompile(`\n\s*\n`)
+
+// ParseMessage separa uma mensagem de commit em subject, corpo e footers.
+// Os footers são os parágrafos finais que começam com um token de footer.
+func ParseMessage(message string) (subject, body string, footers []Footer) {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+	if message == "" {
+		return "", "", nil
+	}
+
+	subject, rest, _ := strings.Cut(message, "\n")
+	subject = strings.TrimSpace(subject)
+	rest = strings.TrimSpace(rest)
+	if rest == "" {
+		return subject, "", nil
+	}
+
+	paragraphs := paragraphRegex.Split(rest, -1)
+	footerStartIndex := len(paragraphs)
+	for i := len(paragraphs) - 1; i >= 0; i-- {
+		if footerRegex.MatchString(paragraphs[i]) {
+			footerStartIndex = i
+		} else {
+			break
+		}
+	}
+
+	body = strings.Join(paragraphs[:footerStartIndex], "\n\n")
+	for _, p := range paragraphs[footerStartIndex:] {
+		for _, line := range strings.Split(p, "\n") {
+			if m := footerRegex.FindStringSubmatch(line); m != nil {
+				footers = append(footers, Footer{Key: m[1], Value: strings.TrimSpace(m[2])})
+				continue
+			}
+			// Linha de continuação do footer anterior
+			last := &footers[len(footers)-1]
+			last.Value = strings.TrimSpace(last.Value + "\n" + strings.TrimSpace(line))
+		}
+	}
+	return subject, body, footers
+}
+
+// logFormat é o formato usado pelo 'git log' para montar um Commit.
+// %x1f (Unit Separator) separa os campos; a mensagem (%B) vem por último
+// para que possa conter qualquer caractere.
+const logFormat = "%H%x1f%h%x1f%an%x1f%ae%x1f%cI%x1f%P%x1f%B"
+
+const logFieldCount = 7
+
+// parseLogRecord converte um registro produzido com logFormat em um Commit.
+func parseLogRecord(record string) (Commit, error) {
+	fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", logFieldCount)
+	if len(fields) != logFieldCount {
+		return Commit{}, fmt.Errorf("registro do git log inesperado: %.70q", record)
+	}
+
+	date, err := time.Parse(time.RFC3339, fields[4])
+	if err != nil {
+		return Commit{}, fmt.Errorf("data inválida no commit %s: %v", fields[0], err)
+	}
+
+	subject, body, footers := ParseMessage(fields[6])
+	parents := strings.Fields(fields[5])
+	return Commit{
+		Hash:          fields[0],
+		ShortHash:     fields[1],
+		Author:        fields[2],
+		AuthorEmail:   fields[3],
+		CommitterDate: date,
+		Subject:       subject,
+		Body:          body,
+		Footers:       footers,
+		Parents:       parents,
+		IsMerge:       len(parents) > 1,
+		Message:       strings.TrimSpace(fields[6]),
+	}, nil
+}
@@ -0,0 +1,88 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Erros conhecidos do repositório. Use errors.Is para identificá-los, já que
+// eles chegam embrulhados em um CommandError ou em mensagens de contexto.
+var (
+	// ErrNoTags indica que nenhuma tag de versão foi encontrada
+	ErrNoTags = errors.New("nenhuma tag de versão encontrada")
+	// ErrNotARepo indica que o diretório atual não está em um repositório git
+	ErrNotARepo = errors.New("o diretório atual não é um repositório git")
+	// ErrDetachedHead indica que HEAD não aponta para um branch
+	ErrDetachedHead = errors.New("HEAD não está em um branch (HEAD destacado)")
+	// ErrShallowClone indica que o clone é raso (shallow) e não contém o
+	// histórico necessário para a operação
+	ErrShallowClone = errors.New("o clone é raso (shallow) e não contém o histórico necessário")
+)
+
+// CommandError é a falha de um comando 'git' (Args inclui o próprio "git").
+// Err, quando presente, é um dos erros conhecidos do pacote (ex: ErrNotARepo),
+// identificado pelo código de saída e pela mensagem do git, que é sempre
+// gerada no locale C.
+type CommandError struct {
+	Args     []string
+	ExitCode int
+	Stderr   string
+	Err      error
+}
+
+func (e *CommandError) Error() string {
+	msg := fmt.Sprintf("erro ao executar comando '%s' (código %d): %s", strings.Join(e.Args, " "), e.ExitCode, e.Stderr)
+	if e.Err != nil {
+		msg += " (" + e.Err.Error() + ")"
+	}
+	return msg
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// missingHistoryMessages são as mensagens do git (locale C) para revisões e
+// objetos que não existem no repositório local
+var missingHistoryMessages = []string{
+	"unknown revision",
+	"bad revision",
+	"Invalid revision range",
+	"Not a valid object name",
+	"bad object",
+	"Could not read",
+	"Failed to traverse parents",
+}
+
+// knownShallow guarda o resultado da última consulta de IsShallow pelo
+// backend exec, para que a classificação de erros não precise executar git
+var knownShallow bool
+
+// classifyError identifica o erro conhecido por trás de uma falha do git.
+// Como os subprocessos rodam com LC_ALL=C, as mensagens não dependem do
+// idioma configurado na máquina.
+func classifyError(exitCode int, stderr string) error {
+	switch {
+	case exitCode == 128 && strings.Contains(stderr, "not a git repository"):
+		return ErrNotARepo
+	case strings.Contains(stderr, "shallow update not allowed"):
+		return ErrShallowClone
+	case knownShallow && missingHistory(stderr):
+		// Uma revisão inexistente em um clone raso quase sempre é histórico
+		// que não foi baixado (ex: a tag anterior)
+		return ErrShallowClone
+	}
+	return nil
+}
+
+// missingHistory verifica se a mensagem do git indica uma revisão ou um
+// objeto ausente
+func missingHistory(stderr string) bool {
+	for _, msg := range missingHistoryMessages {
+		if strings.Contains(stderr, msg) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// command prepara um subprocesso com o locale C, para que as mensagens do
+// git não sejam traduzidas (LANG=pt_BR.UTF-8 nos agentes de build) e possam
+// ser classificadas por classifyError
+func command(name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), "LC_ALL=C", "LANGUAGE=C")
+	return cmd
+}
+
+// runCommand é uma função helper para executar comandos no shell. Uma falha
+// é retornada como *CommandError.
+func runCommand(name string, args ...string) (string, error) {
+	cmd := command(name, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		exitCode := -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", &CommandError{
+			Args:     append([]string{name}, args...),
+			ExitCode: exitCode,
+			Stderr:   msg,
+			Err:      classifyError(exitCode, msg),
+		}
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// execRepository implementa Repository executando o binário 'git'
+type execRepository struct{}
+
+func (execRepository) ListTags(pattern string, mergedOnly bool) ([]string, error) {
+	args := []string{"tag", "--list", pattern}
+	if mergedOnly {
+		args = append(args, "--merged", "HEAD")
+	}
+	out, err := runCommand("git", args...)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+func (execRepository) TagExists(tag string) bool {
+	_, err := runCommand("git", "rev-parse", "-q", "--verify", "refs/tags/"+tag)
+	return err == nil
+}
+
+func (execRepository) IsAncestor(ancestor, ref string) (bool, error) {
+	_, err := runCommand("git", "merge-base", "--is-ancestor", ancestor, ref)
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) && cmdErr.ExitCode == 1 {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (execRepository) Log(commitRange string, paths ...string) ([]Commit, error) {
+	// -z = O git separa cada commit com um NUL byte, que nunca aparece
+	// em uma mensagem de commit
+	args := append([]string{"log", "-z"}, strings.Fields(commitRange)...)
+	args = append(args, "--pretty=format:"+logFormat)
+	if len(paths) > 0 {
+		args = append(args, "--")
+		args = append(args, paths...)
+	}
+	out, err := runCommand("git", args...)
+
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return []Commit{}, nil
+	}
+
+	records := strings.Split(out, "\x00")
+	commits := make([]Commit, 0, len(records))
+	for _, record := range records {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		commit, err := parseLogRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+func (execRepository) CreateTag(tag string, opts TagOptions) error {
+	args := []string{}
+	if opts.SigningFormat != "" {
+		args = append(args, "-c", "gpg.format="+opts.SigningFormat)
+	}
+	args = append(args, "tag")
+	switch {
+	case opts.Sign && opts.SigningKey != "":
+		args = append(args, "--local-user="+opts.SigningKey)
+	case opts.Sign:
+		args = append(args, "--sign")
+	case opts.Annotated:
+		args = append(args, "--annotate")
+	}
+	if opts.Sign || opts.Annotated {
+		// verbatim: as notas de release usam títulos Markdown ("## v1.2.0"),
+		// que o modo padrão removeria como comentários
+		args = append(args, "--cleanup=verbatim", "-m", strings.TrimRight(opts.Message, "\n")+"\n")
+	}
+	args = append(args, tag)
+	if opts.Ref != "" {
+		// ^{commit}: a nova tag aponta para o commit, não para a tag anotada
+		args = append(args, opts.Ref+"^{commit}")
+	}
+	_, err := runCommand("git", args...)
+	return err
+}
+
+func (execRepository) VerifyTag(tag string) error {
+	_, err := runCommand("git", "verify-tag", tag)
+	return err
+}
+
+func (execRepository) CommitFiles(message string, files ...string) error {
+	args := append([]string{"add", "--"}, files...)
+	if _, err := runCommand("git", args...); err != nil {
+		return err
+	}
+	args = append([]string{"commit", "-m", message, "--"}, files...)
+	_, err := runCommand("git", args...)
+	return err
+}
+
+func (execRepository) CheckCommitFiles(files ...string) error {
+	// 'git commit -- <arquivos>' ignora o que mais estiver no índice
+	return nil
+}
+
+func (execRepository) PushHead() error {
+	_, err := runCommand("git", "push", "origin", "HEAD")
+	return err
+}
+
+func (execRepository) PushTag(tag string) error {
+	_, err := runCommand("git", "push", "origin", tag)
+	return err
+}
+
+func (execRepository) RemoteURL(name string) (string, error) {
+	return runCommand("git", "config", "--get", "remote."+name+".url")
+}
+
+func (execRepository) CurrentBranch() (string, error) {
+	branch, err := runCommand("git", "symbolic-ref", "--short", "-q", "HEAD")
+	// Com -q, o código 1 sem mensagem significa que HEAD não é um branch
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) && cmdErr.ExitCode == 1 && cmdErr.Err == nil {
+		cmdErr.Err = ErrDetachedHead
+	}
+	return branch, err
+}
+
+func (execRepository) HeadCommit() (string, error) {
+	return runCommand("git", "rev-parse", "HEAD")
+}
+
+func (execRepository) IsShallow() (bool, error) {
+	out, err := runCommand("git", "rev-parse", "--is-shallow-repository")
+	knownShallow = err == nil && out == "true"
+	return knownShallow, err
+}
+
+func (execRepository) Fetch(deepen int) error {
+	// --deepen parte da fronteira atual do clone; --depth contaria a partir
+	// das refs do remote e não aprofundaria o histórico de HEAD
+	args := []string{"fetch", "--tags", "origin"}
+	if deepen > 0 {
+		args = append(args, fmt.Sprintf("--deepen=%d", deepen))
+	} else {
+		args = append(args, "--unshallow")
+	}
+	_, err := runCommand("git", args...)
+	return err
+}
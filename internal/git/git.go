@@ -1,111 +1,293 @@
 package git
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
-	"os/exec"
+	"net/url"
+	"os"
+	"path/filepath"
 	"sort" // <-- NOVO PACOTE IMPORTADO
 	"strings"
 
 	"github.com/Masterminds/semver/v3" // <-- NOVO PACOTE IMPORTADO (precisará de 'go mod tidy')
 )
 
-// runCommand é uma função helper para executar comandos no shell
-func runCommand(name string, args ...string) (string, error) {
-	cmd := exec.Command(name, args...)
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("erro ao executar comando '%s %s': %s", name, strings.Join(args, " "), stderr.String())
-	}
-	return strings.TrimSpace(stdout.String()), nil
+// initialVersion é a versão usada quando o repositório ainda não tem tags
+const initialVersion = "0.0.0"
+
+// VersionTag é uma tag cujo nome segue o formato configurado e contém uma
+// versão semver válida
+type VersionTag struct {
+	Name    string
+	Version *semver.Version
 }
 
-// GetLatestTag retorna a tag git mais recente
-func GetLatestTag() (string, error) {
-	tag, err := runCommand("git", "describe", "--tags", "--abbrev=0")
+// ListVersionTags lista as tags no formato informado que contêm uma versão
+// semver válida. Tags fora do formato (ex: "deploy-prod") são ignoradas.
+// Se mergedOnly for true, apenas as tags alcançáveis a partir de HEAD.
+func ListVersionTags(format TagFormat, mergedOnly bool) ([]VersionTag, error) {
+	names, err := repo.ListTags(format.Pattern(), mergedOnly)
 	if err != nil {
-		if strings.Contains(err.Error(), "no tags found") || strings.Contains(err.Error(), "cannot describe") {
-			return "v0.0.0", nil
+		return nil, err
+	}
+
+	tags := make([]VersionTag, 0)
+	for _, name := range names {
+		version, ok := format.Version(name)
+		if !ok {
+			continue
 		}
+		v, err := semver.StrictNewVersion(version)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, VersionTag{Name: name, Version: v})
+	}
+	return tags, nil
+}
+
+// GetLatestTag retorna a maior tag de versão alcançável a partir de HEAD no
+// formato informado (ex: "v${version}", "release-${version}").
+// Tags que não seguem o formato ou não são semver válidas (ex: "deploy-prod")
+// são ignoradas, e a maior versão vence pela precedência semver, não pela data.
+// Pré-releases só são consideradas se includePrereleases for true.
+// Se nenhuma tag existir, retorna a tag da versão 0.0.0 nesse formato junto
+// com ErrNoTags.
+func GetLatestTag(format TagFormat, includePrereleases bool) (string, error) {
+	tags, err := ListVersionTags(format, true)
+	if err != nil {
 		return "", err
 	}
-	return tag, nil
+
+	var latest *VersionTag
+	for i, tag := range tags {
+		if tag.Version.Prerelease() != "" && !includePrereleases {
+			continue
+		}
+		if latest == nil || tag.Version.GreaterThan(latest.Version) {
+			latest = &tags[i]
+		}
+	}
+
+	if latest == nil {
+		return format.Tag(initialVersion), ErrNoTags
+	}
+	return latest.Name, nil
+}
+
+// IsAncestor verifica se o commit (ou tag) 'ancestor' é o próprio 'ref' ou
+// um de seus ancestrais
+func IsAncestor(ancestor, ref string) (bool, error) {
+	return repo.IsAncestor(ancestor, ref)
+}
+
+// TagExists verifica se a tag existe no repositório local
+func TagExists(tag string) bool {
+	return repo.TagExists(tag)
+}
+
+// ExcludePath retorna o pathspec que exclui um caminho do filtro de
+// GetCommitsSince e GetCommitsInRange (ex: ":(exclude)services/api")
+func ExcludePath(p string) string {
+	return ":(exclude)" + p
 }
 
-// GetCommitsSince retorna uma lista de mensagens de commit desde uma tag específica
-func GetCommitsSince(tag string) ([]string, error) {
+// GetCommitsSince retorna os commits desde uma tag específica, do mais
+// recente para o mais antigo. Se a tag não existir (repositório sem
+// versões), retorna todo o histórico. Se 'paths' for informado, retorna
+// apenas os commits que alteram arquivos nesses caminhos (e fora dos
+// excluídos com ExcludePath).
+func GetCommitsSince(tag string, paths ...string) ([]Commit, error) {
 	commitRange := fmt.Sprintf("%s..HEAD", tag)
-	if tag == "v0.0.0" {
+	if !TagExists(tag) {
 		commitRange = "HEAD"
 	}
+	return GetCommitsInRange(commitRange, paths...)
+}
 
-	// %B = Corpo inteiro do commit
-	// %x00 = O "NUL byte", um delimitador seguro
-	out, err := runCommand("git", "log", commitRange, "--pretty=format:%B%x00")
+// GetCommitsInRange retorna os commits de um intervalo do git (ex:
+// "origin/main..HEAD", "v1.0.0..v1.1.0", "abc123 --not --remotes"), do mais
+// recente para o mais antigo.
+func GetCommitsInRange(commitRange string, paths ...string) ([]Commit, error) {
+	return repo.Log(commitRange, paths...)
+}
 
-	if err != nil {
-		return nil, err
-	}
-	if out == "" {
-		return []string{}, nil
-	}
+// TagOptions define o tipo da tag criada por CreateTag. O valor zero cria
+// uma tag leve.
+type TagOptions struct {
+	// Annotated cria uma tag anotada, com autor, data e Message
+	Annotated bool
+	Message   string
+	// Sign assina a tag anotada com o formato e a chave informados (vazios =
+	// gpg.format e user.signingKey da configuração do git)
+	Sign          bool
+	SigningFormat string // "openpgp" ou "ssh"
+	SigningKey    string
+	// Ref é o commit (ou tag, resolvida para o seu commit) que recebe a
+	// tag. Vazio = HEAD.
+	Ref string
+}
+
+// CreateTag cria uma nova tag git em HEAD (ou em opts.Ref)
+func CreateTag(tag string, opts TagOptions) error {
+	return repo.CreateTag(tag, opts)
+}
+
+// VerifyTag verifica a assinatura de uma tag; uma tag sem assinatura ou com
+// assinatura inválida é um erro
+func VerifyTag(tag string) error {
+	return repo.VerifyTag(tag)
+}
+
+// CheckCommitFiles verifica se um commit contendo apenas os arquivos
+// informados é possível (ex: no backend go-git, não há outras mudanças
+// preparadas). Deve ser chamada antes de os arquivos serem alterados.
+func CheckCommitFiles(files ...string) error {
+	return repo.CheckCommitFiles(files...)
+}
 
-	// Agora dividimos pelo NUL byte
-	return strings.Split(out, "\x00"), nil
+// CommitFiles cria um commit contendo apenas os arquivos informados
+func CommitFiles(message string, files ...string) error {
+	return repo.CommitFiles(message, files...)
 }
 
-// CreateTag cria uma nova tag git
-func CreateTag(tag string) error {
-	_, err := runCommand("git", "tag", tag)
-	return err
+// GetHooksDir retorna o diretório de hooks do repositório: o definido em
+// core.hooksPath (relativo à raiz do working tree) ou .git/hooks.
+// Ela sempre executa o binário 'git', qualquer que seja o backend: os hooks
+// instalados só rodam dentro do próprio git, e apenas ele resolve o
+// diretório em worktrees e com core.hooksPath relativo.
+func GetHooksDir() (string, error) {
+	if hooksPath, err := runCommand("git", "config", "--path", "--get", "core.hooksPath"); err == nil && hooksPath != "" {
+		if filepath.IsAbs(hooksPath) {
+			return hooksPath, nil
+		}
+		root, err := runCommand("git", "rev-parse", "--show-toplevel")
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(root, hooksPath), nil
+	}
+	return runCommand("git", "rev-parse", "--git-path", "hooks")
+}
+
+// PushHead empurra o branch atual para o repositório remoto (origin)
+func PushHead() error {
+	return repo.PushHead()
 }
 
 // PushTag empurra uma tag para o repositório remoto (origin)
 func PushTag(tag string) error {
-	_, err := runCommand("git", "push", "origin", tag)
-	return err
+	return repo.PushTag(tag)
+}
+
+// Remote descreve o repositório apontado pelo remote 'origin'
+type Remote struct {
+	// Host é o servidor do remote (ex: github.com, gitlab.empresa.com)
+	Host string
+	// Owner é o dono do repositório. No GitLab pode conter subgrupos
+	// (ex: "grupo/subgrupo").
+	Owner string
+	Repo  string
+}
+
+// Path retorna o caminho completo do repositório (ex: "dono/repo")
+func (r *Remote) Path() string {
+	return r.Owner + "/" + r.Repo
+}
+
+// GetRemote lê e analisa a URL do remote 'origin'
+func GetRemote() (*Remote, error) {
+	remoteURL, err := repo.RemoteURL("origin")
+	if err != nil {
+		return nil, err
+	}
+	return parseRemoteURL(remoteURL)
+}
+
+// parseRemoteURL aceita URLs HTTPS, ssh:// e o formato SCP (git@host:dono/repo.git)
+func parseRemoteURL(remoteURL string) (*Remote, error) {
+	var host, path string
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return nil, fmt.Errorf("URL remota inválida: %s", remoteURL)
+		}
+		host = u.Host
+		if u.Scheme != "http" && u.Scheme != "https" {
+			// A porta do SSH não é a porta da API
+			host = u.Hostname()
+		}
+		path = u.Path
+	} else {
+		// Formato SCP: [usuario@]host:dono/repo.git
+		hostPart, pathPart, found := strings.Cut(remoteURL, ":")
+		if !found {
+			return nil, fmt.Errorf("URL remota inválida: %s", remoteURL)
+		}
+		if i := strings.LastIndex(hostPart, "@"); i >= 0 {
+			hostPart = hostPart[i+1:]
+		}
+		host, path = hostPart, pathPart
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	i := strings.LastIndex(path, "/")
+	if host == "" || i <= 0 || i == len(path)-1 {
+		return nil, fmt.Errorf("URL remota inválida: %s", remoteURL)
+	}
+	return &Remote{Host: host, Owner: path[:i], Repo: path[i+1:]}, nil
 }
 
 // GetCurrentRepo extrai o "dono/nome_repo" da URL remota
 func GetCurrentRepo() (owner, repo string, err error) {
-	remoteURL, err := runCommand("git", "config", "--get", "remote.origin.url")
+	remote, err := GetRemote()
 	if err != nil {
 		return "", "", err
 	}
-	if strings.HasPrefix(remoteURL, "git@") {
-		remoteURL = strings.Replace(remoteURL, ":", "/", 1)
-		remoteURL = strings.Replace(remoteURL, "git@", "https://", 1)
+	return remote.Owner, remote.Repo, nil
+}
+
+// GetCurrentBranch retorna o nome do branch atual. Em CI, onde o checkout
+// costuma deixar o HEAD destacado, usa as variáveis do GitHub Actions
+// (GITHUB_REF_NAME) e do GitLab CI (CI_COMMIT_BRANCH).
+func GetCurrentBranch() (string, error) {
+	branch, err := repo.CurrentBranch()
+	if err == nil && branch != "" {
+		return branch, nil
 	}
-	remoteURL = strings.TrimSuffix(remoteURL, ".git")
 
-	parts := strings.Split(remoteURL, "/")
-	if len(parts) < 2 {
-		return "", "", fmt.Errorf("URL remota inválida: %s", remoteURL)
+	if os.Getenv("GITHUB_REF_TYPE") == "branch" {
+		if name := os.Getenv("GITHUB_REF_NAME"); name != "" {
+			return name, nil
+		}
+	}
+	if name := os.Getenv("CI_COMMIT_BRANCH"); name != "" {
+		return name, nil
 	}
-	owner = parts[len(parts)-2]
-	repo = parts[len(parts)-1]
-	return owner, repo, nil
+	if err == nil || errors.Is(err, ErrDetachedHead) {
+		return "", fmt.Errorf("%w e nenhuma variável de CI indica o branch", ErrDetachedHead)
+	}
+	return "", err
+}
+
+// GetHeadCommit retorna o hash do commit apontado por HEAD
+func GetHeadCommit() (string, error) {
+	return repo.HeadCommit()
 }
 
 // --- NOVO ---
 // GetLatestPreReleaseTag encontra a tag de pre-release mais recente para uma
 // versão estável e um canal específico.
-// Ex: baseVersion = "v1.3.0", channel = "beta"
-// Ele procura por "v1.3.0-beta.1", "v1.3.0-beta.2", etc., e retorna a mais alta.
-func GetLatestPreReleaseTag(baseVersion string, channel string) (string, error) {
-	pattern := fmt.Sprintf("%s-%s.*", baseVersion, channel)
-	out, err := runCommand("git", "tag", "--list", pattern, "--sort=v:refname")
+// Ex: format = "v${version}", baseVersion = "1.3.0", channel = "beta"
+// Ele procura por "v1.3.0-beta.1", "v1.3.0-beta.2", etc., e retorna a versão
+// mais alta (sem o formato da tag, ex: "1.3.0-beta.2").
+func GetLatestPreReleaseTag(format TagFormat, baseVersion string, channel string) (string, error) {
+	pattern := format.Tag(fmt.Sprintf("%s-%s.*", baseVersion, channel))
+	tags, err := repo.ListTags(pattern, false)
 	if err != nil {
-		return "", err // Erro ao executar o 'git tag'
-	}
-
-	if out == "" {
-		return "", nil // Nenhuma tag encontrada, não é um erro
+		return "", err // Erro ao listar as tags
 	}
 
-	tags := strings.Split(out, "\n")
 	if len(tags) == 0 {
 		return "", nil // Nenhuma tag encontrada
 	}
@@ -115,7 +297,11 @@ func GetLatestPreReleaseTag(baseVersion string, channel string) (string, error)
 	// Vamos usar uma biblioteca de semver para garantir.
 	vs := make([]*semver.Version, 0)
 	for _, r := range tags {
-		v, err := semver.NewVersion(r)
+		version, ok := format.Version(r)
+		if !ok {
+			continue
+		}
+		v, err := semver.NewVersion(version)
 		if err == nil {
 			vs = append(vs, v)
 		}
@@ -0,0 +1,572 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// httpCredentials são usadas pelo backend go-git para empurrar para remotes
+// HTTP(S). Remotes SSH usam o ssh-agent.
+var httpCredentials *http.BasicAuth
+
+// SetCredentials define o usuário e o token usados pelo backend go-git no
+// push para remotes HTTP(S). O backend exec usa as credenciais do próprio git.
+func SetCredentials(username, token string) {
+	if token == "" {
+		httpCredentials = nil
+		return
+	}
+	httpCredentials = &http.BasicAuth{Username: username, Password: token}
+}
+
+// goGitRepository implementa Repository em Go puro, sem o binário 'git'
+type goGitRepository struct {
+	repo *gogit.Repository
+}
+
+// NewGoGitRepository cria o backend go-git sobre um repositório já aberto,
+// inclusive um em memória (memory.NewStorage()), para uso com UseRepository
+func NewGoGitRepository(r *gogit.Repository) Repository {
+	return &goGitRepository{repo: r}
+}
+
+// openGoGitRepository abre o repositório que contém o diretório informado
+func openGoGitRepository(dir string) (Repository, error) {
+	r, err := gogit.PlainOpenWithOptions(dir, &gogit.PlainOpenOptions{DetectDotGit: true, EnableDotGitCommonDir: true})
+	if errors.Is(err, gogit.ErrRepositoryNotExists) {
+		return nil, ErrNotARepo
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir o repositório git: %v", err)
+	}
+	return NewGoGitRepository(r), nil
+}
+
+func (g *goGitRepository) ListTags(pattern string, mergedOnly bool) ([]string, error) {
+	names, err := g.listTags(pattern, mergedOnly)
+	return names, g.wrapShallow(err)
+}
+
+func (g *goGitRepository) listTags(pattern string, mergedOnly bool) ([]string, error) {
+	var reachable map[plumbing.Hash]bool
+	if mergedOnly {
+		head, err := g.headCommit()
+		if err != nil {
+			return nil, err
+		}
+		reachable, err = ancestors(head)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	iter, err := g.repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0)
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if ok, _ := path.Match(pattern, name); !ok {
+			return nil
+		}
+		if mergedOnly {
+			commit, err := g.tagCommit(ref)
+			if err != nil || !reachable[commit.Hash] {
+				return nil
+			}
+		}
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (g *goGitRepository) TagExists(tag string) bool {
+	_, err := g.repo.Reference(plumbing.NewTagReferenceName(tag), false)
+	return err == nil
+}
+
+func (g *goGitRepository) IsAncestor(ancestor, ref string) (bool, error) {
+	ok, err := g.isAncestor(ancestor, ref)
+	return ok, g.wrapShallow(err)
+}
+
+func (g *goGitRepository) isAncestor(ancestor, ref string) (bool, error) {
+	a, err := g.resolveCommit(ancestor)
+	if err != nil {
+		return false, err
+	}
+	r, err := g.resolveCommit(ref)
+	if err != nil {
+		return false, err
+	}
+	return a.IsAncestor(r)
+}
+
+func (g *goGitRepository) Log(commitRange string, paths ...string) ([]Commit, error) {
+	commits, err := g.log(commitRange, paths...)
+	return commits, g.wrapShallow(err)
+}
+
+func (g *goGitRepository) log(commitRange string, paths ...string) ([]Commit, error) {
+	include, exclude, err := g.parseRange(commitRange)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tudo o que é alcançável a partir das revisões excluídas fica de fora
+	excluded := make(map[plumbing.Hash]bool)
+	for _, c := range exclude {
+		reachable, err := ancestors(c)
+		if err != nil {
+			return nil, err
+		}
+		for h := range reachable {
+			excluded[h] = true
+		}
+	}
+
+	isExcluded := object.CommitFilter(func(c *object.Commit) bool { return excluded[c.Hash] })
+	isIncluded := object.CommitFilter(func(c *object.Commit) bool { return !excluded[c.Hash] })
+	seen := make(map[plumbing.Hash]bool)
+	found := make([]*object.Commit, 0)
+	for _, from := range include {
+		iter := object.NewFilterCommitIter(from, &isIncluded, &isExcluded)
+		err := iter.ForEach(func(c *object.Commit) error {
+			if seen[c.Hash] {
+				return nil
+			}
+			seen[c.Hash] = true
+			if len(paths) > 0 {
+				touches, err := touchesPaths(c, paths)
+				if err != nil || !touches {
+					return err
+				}
+			}
+			found = append(found, c)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Mesma ordem do 'git log': do mais recente para o mais antigo
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Committer.When.After(found[j].Committer.When) })
+
+	commits := make([]Commit, 0, len(found))
+	for _, c := range found {
+		commits = append(commits, newCommit(c))
+	}
+	return commits, nil
+}
+
+func (g *goGitRepository) CreateTag(tag string, opts TagOptions) error {
+	if opts.Sign {
+		return fmt.Errorf("o backend go-git não assina tags; use o backend exec")
+	}
+	ref := opts.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	commit, err := g.resolveCommit(ref)
+	if err != nil {
+		return err
+	}
+	var tagOpts *gogit.CreateTagOptions
+	if opts.Annotated {
+		// O autor (tagger) vem do user.name/user.email da configuração do git
+		tagOpts = &gogit.CreateTagOptions{Message: opts.Message}
+	}
+	_, err = g.repo.CreateTag(tag, commit.Hash, tagOpts)
+	return err
+}
+
+func (g *goGitRepository) VerifyTag(tag string) error {
+	ref, err := g.repo.Tag(tag)
+	if err != nil {
+		return err
+	}
+	obj, err := g.repo.TagObject(ref.Hash())
+	if err != nil || obj.PGPSignature == "" {
+		return fmt.Errorf("a tag '%s' não está assinada", tag)
+	}
+	// Sem o chaveiro público não é possível validar a assinatura em Go puro
+	return nil
+}
+
+func (g *goGitRepository) CheckCommitFiles(files ...string) error {
+	wt, err := g.repo.Worktree()
+	if err != nil {
+		return err
+	}
+	return checkStaged(wt, files)
+}
+
+func (g *goGitRepository) CommitFiles(message string, files ...string) error {
+	wt, err := g.repo.Worktree()
+	if err != nil {
+		return err
+	}
+	if err := checkStaged(wt, files); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if _, err := wt.Add(file); err != nil {
+			return fmt.Errorf("erro ao adicionar '%s': %v", file, err)
+		}
+	}
+	_, err = wt.Commit(message, &gogit.CommitOptions{})
+	return err
+}
+
+// checkStaged recusa mudanças já preparadas (staged) fora dos arquivos
+// informados: o go-git sempre commita o índice inteiro, enquanto 'git commit
+// -- <arquivos>' leva apenas os arquivos informados, então elas entrariam no
+// commit de release
+func checkStaged(wt *gogit.Worktree, files []string) error {
+	listed := make(map[string]bool, len(files))
+	for _, file := range files {
+		listed[path.Clean(filepath.ToSlash(file))] = true
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return err
+	}
+	staged := make([]string, 0)
+	for file, st := range status {
+		if st.Staging != gogit.Unmodified && st.Staging != gogit.Untracked && !listed[file] {
+			staged = append(staged, file)
+		}
+	}
+	if len(staged) > 0 {
+		sort.Strings(staged)
+		return fmt.Errorf("há mudanças preparadas (staged) fora do commit de release: %s. Faça o commit ou 'git restore --staged' antes", strings.Join(staged, ", "))
+	}
+	return nil
+}
+
+func (g *goGitRepository) PushHead() error {
+	head, err := g.repo.Head()
+	if err != nil {
+		return err
+	}
+	if !head.Name().IsBranch() {
+		return ErrDetachedHead
+	}
+	return g.push(gitconfig.RefSpec(head.Name() + ":" + head.Name()))
+}
+
+func (g *goGitRepository) PushTag(tag string) error {
+	name := plumbing.NewTagReferenceName(tag)
+	return g.push(gitconfig.RefSpec(name + ":" + name))
+}
+
+func (g *goGitRepository) RemoteURL(name string) (string, error) {
+	remote, err := g.repo.Remote(name)
+	if err != nil {
+		return "", fmt.Errorf("remote '%s': %v", name, err)
+	}
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", fmt.Errorf("remote '%s' sem URL", name)
+	}
+	return urls[0], nil
+}
+
+func (g *goGitRepository) CurrentBranch() (string, error) {
+	head, err := g.repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return "", err
+	}
+	if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return "", ErrDetachedHead
+	}
+	return head.Target().Short(), nil
+}
+
+func (g *goGitRepository) HeadCommit() (string, error) {
+	head, err := g.repo.Head()
+	if err != nil {
+		return "", err
+	}
+	return head.Hash().String(), nil
+}
+
+func (g *goGitRepository) IsShallow() (bool, error) {
+	shallow, err := g.repo.Storer.Shallow()
+	return len(shallow) > 0, err
+}
+
+func (g *goGitRepository) Fetch(deepen int) error {
+	// O go-git não remove as fronteiras antigas do arquivo .git/shallow ao
+	// aprofundar o histórico, então o clone continuaria raso
+	return fmt.Errorf("%w: o backend go-git não aprofunda clones rasos; baixe o histórico completo antes ou use o backend exec", ErrShallowClone)
+}
+
+// push empurra a refspec para o remote 'origin'
+func (g *goGitRepository) push(refSpec gitconfig.RefSpec) error {
+	opts := &gogit.PushOptions{RemoteName: "origin", RefSpecs: []gitconfig.RefSpec{refSpec}}
+	if url, err := g.RemoteURL("origin"); err == nil && httpCredentials != nil {
+		if endpoint, err := transport.NewEndpoint(url); err == nil && strings.HasPrefix(endpoint.Protocol, "http") {
+			opts.Auth = httpCredentials
+		}
+	}
+	err := g.repo.Push(opts)
+	if errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return nil
+	}
+	return err
+}
+
+// wrapShallow identifica, em um clone raso, as falhas causadas por objetos
+// que não foram baixados
+func (g *goGitRepository) wrapShallow(err error) error {
+	if err == nil {
+		return nil
+	}
+	if shallow, _ := g.IsShallow(); shallow {
+		return fmt.Errorf("%w: %v", ErrShallowClone, err)
+	}
+	return err
+}
+
+// headCommit retorna o commit apontado por HEAD
+func (g *goGitRepository) headCommit() (*object.Commit, error) {
+	return g.resolveCommit("HEAD")
+}
+
+// resolveCommit resolve uma revisão (hash, branch, tag, HEAD) para um commit
+func (g *goGitRepository) resolveCommit(rev string) (*object.Commit, error) {
+	hash, err := g.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, fmt.Errorf("revisão '%s' não encontrada: %v", rev, err)
+	}
+	return g.repo.CommitObject(*hash)
+}
+
+// tagCommit retorna o commit de uma tag, seja ela leve ou anotada
+func (g *goGitRepository) tagCommit(ref *plumbing.Reference) (*object.Commit, error) {
+	if tag, err := g.repo.TagObject(ref.Hash()); err == nil {
+		return tag.Commit()
+	}
+	return g.repo.CommitObject(ref.Hash())
+}
+
+// parseRange interpreta a sintaxe de intervalos aceita por Log: "A..B",
+// "B", "^A B" e "B --not --remotes"
+func (g *goGitRepository) parseRange(commitRange string) (include, exclude []*object.Commit, err error) {
+	add := func(rev string, negated bool) error {
+		c, err := g.resolveCommit(rev)
+		if err != nil {
+			return err
+		}
+		if negated {
+			exclude = append(exclude, c)
+		} else {
+			include = append(include, c)
+		}
+		return nil
+	}
+
+	not := false
+	for _, token := range strings.Fields(commitRange) {
+		switch {
+		case token == "--not":
+			not = !not
+		case token == "--remotes":
+			refs, err := g.repo.References()
+			if err != nil {
+				return nil, nil, err
+			}
+			err = refs.ForEach(func(ref *plumbing.Reference) error {
+				if !ref.Name().IsRemote() || ref.Type() != plumbing.HashReference {
+					return nil
+				}
+				c, err := g.repo.CommitObject(ref.Hash())
+				if err != nil {
+					return nil
+				}
+				if not {
+					exclude = append(exclude, c)
+				} else {
+					include = append(include, c)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+		case strings.Contains(token, ".."):
+			from, to, _ := strings.Cut(token, "..")
+			if from == "" {
+				from = "HEAD"
+			}
+			if to == "" {
+				to = "HEAD"
+			}
+			if err := add(from, !not); err != nil {
+				return nil, nil, err
+			}
+			if err := add(to, not); err != nil {
+				return nil, nil, err
+			}
+		case strings.HasPrefix(token, "^"):
+			if err := add(token[1:], !not); err != nil {
+				return nil, nil, err
+			}
+		default:
+			if err := add(token, not); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if len(include) == 0 {
+		return nil, nil, fmt.Errorf("intervalo de commits vazio: '%s'", commitRange)
+	}
+	return include, exclude, nil
+}
+
+// ancestors retorna o commit e todos os seus ancestrais
+func ancestors(from *object.Commit) (map[plumbing.Hash]bool, error) {
+	reachable := make(map[plumbing.Hash]bool)
+	err := object.NewCommitPreorderIter(from, nil, nil).ForEach(func(c *object.Commit) error {
+		reachable[c.Hash] = true
+		return nil
+	})
+	if err != nil && !errors.Is(err, storer.ErrStop) {
+		return nil, err
+	}
+	return reachable, nil
+}
+
+// touchesPaths informa se o commit altera arquivos nos caminhos informados.
+// Como no 'git log -- <caminhos>', um merge só conta se diferir de todos os
+// pais nesses caminhos.
+func touchesPaths(c *object.Commit, paths []string) (bool, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return false, err
+	}
+	if c.NumParents() == 0 {
+		return treeTouchesPaths(nil, tree, paths)
+	}
+	touches := true
+	err = c.Parents().ForEach(func(parent *object.Commit) error {
+		parentTree, err := parent.Tree()
+		if err != nil {
+			return err
+		}
+		differs, err := treeTouchesPaths(parentTree, tree, paths)
+		if err != nil {
+			return err
+		}
+		if !differs {
+			touches = false
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, storer.ErrStop) {
+		return false, err
+	}
+	return touches, nil
+}
+
+// treeTouchesPaths informa se a diferença entre duas árvores inclui arquivos
+// dentro dos caminhos informados
+func treeTouchesPaths(from, to *object.Tree, paths []string) (bool, error) {
+	changes, err := object.DiffTree(from, to)
+	if err != nil {
+		return false, err
+	}
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name != "" && pathspecsMatch(paths, name) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// pathspecsMatch informa se o arquivo 'name' casa com os caminhos informados
+// como no 'git log -- <caminhos>': está em algum dos caminhos incluídos (ou
+// não há nenhum) e em nenhum dos excluídos (":(exclude)dir" ou ":!dir")
+func pathspecsMatch(paths []string, name string) bool {
+	included, hasIncludes := false, false
+	for _, p := range paths {
+		if dir, ok := excludedPath(p); ok {
+			if pathContains(dir, name) {
+				return false
+			}
+			continue
+		}
+		hasIncludes = true
+		if pathContains(p, name) {
+			included = true
+		}
+	}
+	return included || !hasIncludes
+}
+
+// excludedPath retorna o caminho de um pathspec de exclusão
+func excludedPath(pathspec string) (string, bool) {
+	for _, magic := range []string{":(exclude)", ":!", ":^"} {
+		if dir, ok := strings.CutPrefix(pathspec, magic); ok {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+// pathContains informa se o arquivo 'name' (relativo à raiz) está dentro do
+// caminho 'dir', normalizado como o git faz com os pathspecs (ex:
+// "./services/api/" -> "services/api"; "." é o repositório inteiro)
+func pathContains(dir, name string) bool {
+	dir = path.Clean(strings.Trim(dir, "/"))
+	if dir == "." {
+		return true
+	}
+	return name == dir || strings.HasPrefix(name, dir+"/")
+}
+
+// newCommit converte um commit do go-git no Commit do pacote
+func newCommit(c *object.Commit) Commit {
+	subject, body, footers := ParseMessage(c.Message)
+	parents := make([]string, 0, len(c.ParentHashes))
+	for _, h := range c.ParentHashes {
+		parents = append(parents, h.String())
+	}
+	hash := c.Hash.String()
+	return Commit{
+		Hash:          hash,
+		ShortHash:     hash[:7],
+		Author:        c.Author.Name,
+		AuthorEmail:   c.Author.Email,
+		CommitterDate: c.Committer.When,
+		Subject:       subject,
+		Body:          body,
+		Footers:       footers,
+		Parents:       parents,
+		IsMerge:       len(parents) > 1,
+		Message:       strings.TrimSpace(c.Message),
+	}
+}
@@ -0,0 +1,209 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// testRepo é um repositório em memória com um working tree em memória
+type testRepo struct {
+	t    *testing.T
+	repo *gogit.Repository
+	wt   *gogit.Worktree
+	when time.Time
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	r, err := gogit.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := r.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.User.Name = "Release Bot"
+	cfg.User.Email = "release@example.com"
+	if err := r.SetConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	wt, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testRepo{t: t, repo: r, wt: wt, when: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+// commit grava os arquivos (caminho -> conteúdo) e cria um commit, um minuto
+// depois do anterior para que a ordem do log seja determinística
+func (r *testRepo) commit(message string, files map[string]string) plumbing.Hash {
+	r.t.Helper()
+	for name, content := range files {
+		if err := util.WriteFile(r.wt.Filesystem, name, []byte(content), 0644); err != nil {
+			r.t.Fatal(err)
+		}
+		if _, err := r.wt.Add(name); err != nil {
+			r.t.Fatal(err)
+		}
+	}
+	r.when = r.when.Add(time.Minute)
+	hash, err := r.wt.Commit(message, &gogit.CommitOptions{
+		Author: &object.Signature{Name: "Dev", Email: "dev@example.com", When: r.when},
+	})
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	return hash
+}
+
+func (r *testRepo) tag(name string, hash plumbing.Hash) {
+	r.t.Helper()
+	if _, err := r.repo.CreateTag(name, hash, nil); err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+func (r *testRepo) checkout(branch string, create bool) {
+	r.t.Helper()
+	if err := r.wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(branch), Create: create}); err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+func subjects(commits []Commit) []string {
+	out := make([]string, 0, len(commits))
+	for _, c := range commits {
+		out = append(out, c.Subject)
+	}
+	return out
+}
+
+func TestGoGitListTagsMergedOnly(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v1.0.0", r.commit("feat: init", map[string]string{"a.go": "a"}))
+	r.tag("deploy-prod", r.commit("fix: a", map[string]string{"a.go": "b"}))
+
+	// v2.0.0-beta.1 fica em outro branch, fora do histórico de main
+	r.checkout("next", true)
+	r.tag("v2.0.0-beta.1", r.commit("feat!: b", map[string]string{"b.go": "b"}))
+	r.checkout("master", false)
+
+	repo := NewGoGitRepository(r.repo)
+	tests := []struct {
+		mergedOnly bool
+		want       []string
+	}{
+		{mergedOnly: true, want: []string{"v1.0.0"}},
+		{mergedOnly: false, want: []string{"v1.0.0", "v2.0.0-beta.1"}},
+	}
+	for _, tt := range tests {
+		got, err := repo.ListTags("v[0-9]*", tt.mergedOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ListTags(mergedOnly=%t) = %v, esperado %v", tt.mergedOnly, got, tt.want)
+		}
+	}
+}
+
+func TestGoGitLog(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v1.0.0", r.commit("feat: init", map[string]string{"main.go": "a", "services/api/main.go": "a"}))
+	r.commit("feat(api): endpoint", map[string]string{"services/api/main.go": "b"})
+	r.commit("fix: root", map[string]string{"main.go": "b"})
+	r.commit("docs: api", map[string]string{"services/api-docs/README.md": "a"})
+
+	repo := NewGoGitRepository(r.repo)
+	tests := []struct {
+		name        string
+		commitRange string
+		paths       []string
+		want        []string
+	}{
+		{name: "intervalo", commitRange: "v1.0.0..HEAD", want: []string{"docs: api", "fix: root", "feat(api): endpoint"}},
+		{name: "histórico inteiro", commitRange: "HEAD", want: []string{"docs: api", "fix: root", "feat(api): endpoint", "feat: init"}},
+		{name: "exclusão com --not", commitRange: "HEAD --not v1.0.0", want: []string{"docs: api", "fix: root", "feat(api): endpoint"}},
+		{name: "pacote", commitRange: "v1.0.0..HEAD", paths: []string{"services/api"}, want: []string{"feat(api): endpoint"}},
+		{name: "pacote com ./ e /", commitRange: "v1.0.0..HEAD", paths: []string{"./services/api/"}, want: []string{"feat(api): endpoint"}},
+		{name: "raiz sem o pacote aninhado", commitRange: "v1.0.0..HEAD", paths: []string{".", ExcludePath("services/api")}, want: []string{"docs: api", "fix: root"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits, err := repo.Log(tt.commitRange, tt.paths...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := subjects(commits); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Log(%q, %v) = %v, esperado %v", tt.commitRange, tt.paths, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoGitCreateTag(t *testing.T) {
+	r := newTestRepo(t)
+	first := r.commit("feat: init", map[string]string{"a.go": "a"})
+	r.tag("v1.1.0-rc.1", first)
+	head := r.commit("fix: a", map[string]string{"a.go": "b"})
+
+	repo := NewGoGitRepository(r.repo)
+
+	// Tag leve em HEAD
+	if err := repo.CreateTag("v1.0.1", TagOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	ref, err := r.repo.Tag("v1.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.Hash() != head {
+		t.Errorf("v1.0.1 aponta para %s, esperado HEAD (%s)", ref.Hash(), head)
+	}
+	if !repo.TagExists("v1.0.1") {
+		t.Error("TagExists(v1.0.1) = false")
+	}
+
+	// Tag anotada no commit de outra tag (promoção da pré-release)
+	opts := TagOptions{Annotated: true, Message: "Release v1.1.0\n\n## v1.1.0", Ref: "v1.1.0-rc.1"}
+	if err := repo.CreateTag("v1.1.0", opts); err != nil {
+		t.Fatal(err)
+	}
+	ref, err = r.repo.Tag("v1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj, err := r.repo.TagObject(ref.Hash())
+	if err != nil {
+		t.Fatalf("v1.1.0 não é uma tag anotada: %v", err)
+	}
+	if obj.Target != first {
+		t.Errorf("v1.1.0 aponta para %s, esperado o commit da pré-release (%s)", obj.Target, first)
+	}
+	if obj.Message != "Release v1.1.0\n\n## v1.1.0\n" {
+		t.Errorf("mensagem da tag = %q", obj.Message)
+	}
+	if obj.Tagger.Email != "release@example.com" {
+		t.Errorf("autor da tag = %q, esperado o user.email do repositório", obj.Tagger.Email)
+	}
+	if err := repo.VerifyTag("v1.1.0"); err == nil {
+		t.Error("VerifyTag aceitou uma tag sem assinatura")
+	}
+
+	// Assinar exige o backend exec
+	if err := repo.CreateTag("v1.1.1", TagOptions{Annotated: true, Message: "x", Sign: true}); err == nil {
+		t.Error("CreateTag com Sign não retornou erro")
+	}
+	if repo.TagExists("v1.1.1") {
+		t.Error("a tag v1.1.1 foi criada apesar do erro")
+	}
+}
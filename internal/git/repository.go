@@ -0,0 +1,82 @@
+package git
+
+import (
+	"fmt"
+)
+
+// Backends disponíveis para acessar o repositório
+const (
+	// BackendExec executa o binário 'git' (padrão)
+	BackendExec = "exec"
+	// BackendGoGit usa a implementação em Go puro (go-git), sem depender do
+	// binário 'git' (ex: em containers distroless)
+	BackendGoGit = "go-git"
+)
+
+// Repository são as operações de baixo nível sobre o repositório usadas pelas
+// funções do pacote (GetLatestTag, GetCommitsSince, CreateTag, PushTag...).
+type Repository interface {
+	// ListTags retorna os nomes das tags que casam com o padrão glob (ex:
+	// "v[0-9]*"). Se mergedOnly for true, apenas as alcançáveis a partir de HEAD.
+	ListTags(pattern string, mergedOnly bool) ([]string, error)
+	// TagExists verifica se a tag existe no repositório local
+	TagExists(tag string) bool
+	// IsAncestor verifica se 'ancestor' é o próprio 'ref' ou um ancestral dele
+	IsAncestor(ancestor, ref string) (bool, error)
+	// Log retorna os commits de um intervalo (ex: "v1.0.0..HEAD"), do mais
+	// recente para o mais antigo, opcionalmente filtrados por caminhos
+	Log(commitRange string, paths ...string) ([]Commit, error)
+	// CreateTag cria uma tag em HEAD ou opts.Ref (leve, anotada ou assinada)
+	CreateTag(tag string, opts TagOptions) error
+	// VerifyTag verifica a assinatura de uma tag
+	VerifyTag(tag string) error
+	// CheckCommitFiles verifica, antes de qualquer arquivo ser alterado, se
+	// CommitFiles conseguirá commitar apenas os arquivos informados
+	CheckCommitFiles(files ...string) error
+	// CommitFiles cria um commit contendo apenas os arquivos informados
+	CommitFiles(message string, files ...string) error
+	// PushHead empurra o branch atual para o remote 'origin'
+	PushHead() error
+	// PushTag empurra uma tag para o remote 'origin'
+	PushTag(tag string) error
+	// RemoteURL retorna a URL de um remote (ex: "origin")
+	RemoteURL(name string) (string, error)
+	// CurrentBranch retorna o branch de HEAD, ou erro se HEAD estiver destacado
+	CurrentBranch() (string, error)
+	// HeadCommit retorna o hash do commit apontado por HEAD
+	HeadCommit() (string, error)
+	// IsShallow verifica se o repositório é um clone raso
+	IsShallow() (bool, error)
+	// Fetch baixa do 'origin' as tags e aprofunda o histórico atual em
+	// 'deepen' commits além da fronteira do clone raso; 0 baixa o histórico
+	// completo
+	Fetch(deepen int) error
+}
+
+// repo é o backend usado pelas funções do pacote
+var repo Repository = execRepository{}
+
+// SetBackend escolhe o backend ("exec" ou "go-git") usado pelas funções do
+// pacote. Vazio mantém o padrão (exec).
+func SetBackend(backend string) error {
+	switch backend {
+	case "", BackendExec:
+		repo = execRepository{}
+		return nil
+	case BackendGoGit:
+		r, err := openGoGitRepository(".")
+		if err != nil {
+			return err
+		}
+		repo = r
+		return nil
+	default:
+		return fmt.Errorf("backend git inválido '%s' (use '%s' ou '%s')", backend, BackendExec, BackendGoGit)
+	}
+}
+
+// UseRepository troca o backend por uma implementação qualquer (ex: um
+// repositório em memória criado com NewGoGitRepository)
+func UseRepository(r Repository) {
+	repo = r
+}
@@ -0,0 +1,72 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+)
+
+// fetchSteps são os aprofundamentos sucessivos (em commits, somados) de um
+// clone raso até que a última tag de versão fique alcançável a partir de
+// HEAD. Se nenhum bastar, o histórico completo é baixado.
+var fetchSteps = []int{50, 250, 1000}
+
+// IsShallow verifica se o repositório é um clone raso (ex: 'fetch-depth: 1'
+// no actions/checkout)
+func IsShallow() (bool, error) {
+	return repo.IsShallow()
+}
+
+// EnsureHistory garante que um clone raso tenha as tags e o histórico
+// necessários para calcular a versão: sem eles, a última tag não é encontrada
+// ou o intervalo tag..HEAD não tem início, e a versão calculada é errada.
+//
+// Se fetch for false, um clone raso é um erro (ErrShallowClone). Caso
+// contrário, as tags são baixadas e o histórico é aprofundado até que cada
+// formato tenha uma tag de versão alcançável a partir de HEAD; se algum
+// formato ainda não tiver tags, o histórico completo é baixado (--unshallow).
+// includePrereleases deve ser o mesmo usado depois em GetLatestTag: uma
+// pré-release alcançável não basta quando o cálculo parte da última estável.
+// Retorna true se algo foi baixado.
+func EnsureHistory(formats []TagFormat, includePrereleases, fetch bool) (bool, error) {
+	shallow, err := repo.IsShallow()
+	if err != nil || !shallow {
+		return false, err
+	}
+	if !fetch {
+		return false, fmt.Errorf("%w: o repositório foi clonado com profundidade limitada e as tags e commits anteriores podem não ter sido baixados", ErrShallowClone)
+	}
+
+	for _, deepen := range fetchSteps {
+		if err := repo.Fetch(deepen); err != nil {
+			return true, fmt.Errorf("erro ao aprofundar o histórico em %d commits: %w", deepen, err)
+		}
+		done, err := historyReachesTags(formats, includePrereleases)
+		if err != nil || done {
+			return true, err
+		}
+	}
+
+	if err := repo.Fetch(0); err != nil {
+		return true, fmt.Errorf("erro ao baixar o histórico completo: %w", err)
+	}
+	return true, nil
+}
+
+// historyReachesTags verifica se o repositório deixou de ser raso ou se todos
+// os formatos já têm uma tag de versão alcançável a partir de HEAD
+func historyReachesTags(formats []TagFormat, includePrereleases bool) (bool, error) {
+	shallow, err := repo.IsShallow()
+	if err != nil || !shallow {
+		return true, err
+	}
+	for _, format := range formats {
+		_, err := GetLatestTag(format, includePrereleases)
+		if errors.Is(err, ErrNoTags) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,45 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// versionPlaceholder é substituído pela versão no formato da tag
+const versionPlaceholder = "${version}"
+
+// TagFormat descreve como uma versão vira nome de tag. "${version}" é
+// substituído pela versão semântica sem o "v" (ex: "v${version}" -> v1.2.3,
+// "release-${version}" -> release-1.2.3, "services/api/v${version}").
+type TagFormat string
+
+// DefaultTagFormat é o formato usado quando nenhum é configurado
+const DefaultTagFormat TagFormat = "v" + versionPlaceholder
+
+// Validate verifica se o formato contém "${version}" exatamente uma vez
+func (f TagFormat) Validate() error {
+	if strings.Count(string(f), versionPlaceholder) != 1 {
+		return fmt.Errorf("formato de tag inválido '%s': deve conter %s exatamente uma vez", f, versionPlaceholder)
+	}
+	return nil
+}
+
+// Tag monta o nome da tag para uma versão (ex: "1.2.3" -> "v1.2.3")
+func (f TagFormat) Tag(version string) string {
+	return strings.Replace(string(f), versionPlaceholder, version, 1)
+}
+
+// Version extrai a versão de uma tag neste formato. Retorna false se a tag
+// não segue o formato.
+func (f TagFormat) Version(tag string) (string, bool) {
+	prefix, suffix, _ := strings.Cut(string(f), versionPlaceholder)
+	if !strings.HasPrefix(tag, prefix) || !strings.HasSuffix(tag, suffix) || len(tag) <= len(prefix)+len(suffix) {
+		return "", false
+	}
+	return tag[len(prefix) : len(tag)-len(suffix)], true
+}
+
+// Pattern retorna o padrão (glob do git) que encontra as tags deste formato
+func (f TagFormat) Pattern() string {
+	return f.Tag("[0-9]*")
+}
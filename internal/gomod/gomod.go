@@ -0,0 +1,187 @@
+package gomod
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// ModulePath lê o caminho do módulo declarado no go.mod do diretório.
+// Retorna os.ErrNotExist (verificável com errors.Is) se não houver go.mod.
+func ModulePath(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	modulePath := modfile.ModulePath(data)
+	if modulePath == "" {
+		return "", fmt.Errorf("diretiva 'module' não encontrada em %s", filepath.Join(dir, "go.mod"))
+	}
+	return modulePath, nil
+}
+
+// PathForVersion retorna o caminho que o módulo deve ter para publicar a
+// versão informada. A partir da v2, o Go exige o sufixo /vN no caminho
+// (ex: example.com/lib -> example.com/lib/v2).
+func PathForVersion(modulePath, version string) (string, error) {
+	v, err := semver.NewVersion(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return "", fmt.Errorf("versão inválida '%s': %v", version, err)
+	}
+
+	prefix, pathMajor, ok := module.SplitPathVersion(modulePath)
+	if !ok {
+		return "", fmt.Errorf("caminho de módulo inválido: %s", modulePath)
+	}
+	// gopkg.in usa o formato '.vN', que já faz parte do caminho publicado
+	if strings.HasPrefix(pathMajor, ".") {
+		return modulePath, nil
+	}
+
+	if v.Major() < 2 {
+		return prefix, nil
+	}
+	return fmt.Sprintf("%s/v%d", prefix, v.Major()), nil
+}
+
+// Rewrite troca o caminho do módulo no go.mod de dir e atualiza todos os
+// imports internos (oldPath e seus subpacotes) nos arquivos .go. Módulos
+// aninhados e os diretórios vendor/testdata são ignorados.
+// Todas as alterações são calculadas antes de qualquer arquivo ser gravado:
+// um arquivo .go inválido não deixa o módulo migrado pela metade.
+// Retorna os arquivos alterados.
+func Rewrite(dir, oldPath, newPath string) ([]string, error) {
+	rewrites := make([]rewrite, 0)
+
+	// 1. go.mod
+	goModFile := filepath.Join(dir, "go.mod")
+	data, err := os.ReadFile(goModFile)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.Parse(goModFile, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.AddModuleStmt(newPath); err != nil {
+		return nil, err
+	}
+	out, err := f.Format()
+	if err != nil {
+		return nil, err
+	}
+	rewrites = append(rewrites, rewrite{path: goModFile, content: out, perm: 0644})
+
+	// 2. Imports nos arquivos .go
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == dir {
+				return nil
+			}
+			name := d.Name()
+			if name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			// Outro módulo: não pertence a este caminho
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		r, err := rewriteImports(path, oldPath, newPath)
+		if err != nil {
+			return err
+		}
+		if r != nil {
+			rewrites = append(rewrites, *r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Gravar o go.mod e os arquivos .go
+	changed := make([]string, 0, len(rewrites))
+	for _, r := range rewrites {
+		if err := os.WriteFile(r.path, r.content, r.perm); err != nil {
+			return changed, err
+		}
+		changed = append(changed, r.path)
+	}
+	return changed, nil
+}
+
+// rewrite é o novo conteúdo de um arquivo, ainda não gravado
+type rewrite struct {
+	path    string
+	content []byte
+	perm    fs.FileMode
+}
+
+// rewriteImports calcula o novo conteúdo de um arquivo .go com os imports
+// reescritos, preservando o resto (formatação e comentários) byte a byte.
+// Retorna nil se nenhum import mudar.
+func rewriteImports(path, oldPath, newPath string) (*rewrite, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	type edit struct {
+		start, end int
+		text       string
+	}
+	edits := make([]edit, 0)
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if importPath != oldPath && !strings.HasPrefix(importPath, oldPath+"/") {
+			continue
+		}
+		edits = append(edits, edit{
+			start: fset.Position(imp.Path.Pos()).Offset,
+			end:   fset.Position(imp.Path.End()).Offset,
+			text:  strconv.Quote(newPath + strings.TrimPrefix(importPath, oldPath)),
+		})
+	}
+	if len(edits) == 0 {
+		return nil, nil
+	}
+
+	// Aplica do fim para o início para não invalidar os offsets
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for _, e := range edits {
+		src = append(src[:e.start], append([]byte(e.text), src[e.end:]...)...)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return &rewrite{path: path, content: src, perm: info.Mode().Perm()}, nil
+}
@@ -0,0 +1,134 @@
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// marker identifica os hooks escritos por esta ferramenta, para que a
+// reinstalação e a desinstalação nunca apaguem hooks de terceiros
+const marker = "# Instalado por go-release-manager install-hooks"
+
+// chainedSuffix é o sufixo dado a um hook existente, que passa a ser
+// executado antes do nosso (ex: commit-msg -> commit-msg.local)
+const chainedSuffix = ".local"
+
+// Hooks suportados
+const (
+	CommitMsg = "commit-msg"
+	PrePush   = "pre-push"
+)
+
+// Script gera o conteúdo do hook. 'binary' é o caminho do executável atual;
+// se ele não existir mais quando o hook rodar, o go-release-manager do PATH
+// é usado.
+func Script(name, binary string) (string, error) {
+	var body string
+	switch name {
+	case CommitMsg:
+		body = `"$GRM" lint "$1"`
+	case PrePush:
+		body = `z40=0000000000000000000000000000000000000000
+printf '%s\n' "$input" | while read -r local_ref local_sha remote_ref remote_sha; do
+	[ -z "$local_sha" ] && continue
+	# Branch apagado: nada a validar
+	[ "$local_sha" = "$z40" ] && continue
+	if [ "$remote_sha" = "$z40" ]; then
+		# Branch novo: apenas os commits que ainda não estão no remoto
+		range="$local_sha --not --remotes"
+	else
+		range="$remote_sha..$local_sha"
+	fi
+	"$GRM" lint --range "$range" || exit 1
+done`
+	default:
+		return "", fmt.Errorf("hook não suportado: %s", name)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	sb.WriteString(marker + "\n")
+	fmt.Fprintf(&sb, "# Valida as mensagens de commit com as regras do .go-releaserc.yml.\n\n")
+	fmt.Fprintf(&sb, "GRM=%s\n", shellQuote(filepath.ToSlash(binary)))
+	sb.WriteString("[ -x \"$GRM\" ] || GRM=go-release-manager\n")
+	sb.WriteString("HOOK_DIR=$(dirname \"$0\")\n\n")
+	if name == PrePush {
+		// O git envia as refs pela entrada padrão, que só pode ser lida uma vez
+		sb.WriteString("input=$(cat)\n\n")
+	}
+	fmt.Fprintf(&sb, "# Hook que já existia antes da instalação\nif [ -x \"$HOOK_DIR/%s%s\" ]; then\n", name, chainedSuffix)
+	if name == PrePush {
+		fmt.Fprintf(&sb, "\tprintf '%%s\\n' \"$input\" | \"$HOOK_DIR/%s%s\" \"$@\" || exit $?\n", name, chainedSuffix)
+	} else {
+		fmt.Fprintf(&sb, "\t\"$HOOK_DIR/%s%s\" \"$@\" || exit $?\n", name, chainedSuffix)
+	}
+	sb.WriteString("fi\n\n")
+	sb.WriteString(body + "\n")
+	return sb.String(), nil
+}
+
+// Install escreve o hook no diretório de hooks. Um hook existente que não
+// foi instalado por esta ferramenta é renomeado para <nome>.local e
+// encadeado (executado antes do nosso). Retorna true se houve encadeamento.
+func Install(dir, name, script string) (bool, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return false, err
+	}
+	path := filepath.Join(dir, name)
+	chained := false
+
+	existing, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return false, err
+	case !strings.Contains(string(existing), marker):
+		local := path + chainedSuffix
+		if _, err := os.Stat(local); err == nil {
+			return false, fmt.Errorf("%s já existe; não é possível encadear o hook atual de %s", local, path)
+		}
+		if err := os.Rename(path, local); err != nil {
+			return false, err
+		}
+		chained = true
+	}
+
+	return chained, os.WriteFile(path, []byte(script), 0755)
+}
+
+// Uninstall remove o hook instalado por esta ferramenta e restaura o hook
+// encadeado, se houver. Hooks de terceiros não são tocados.
+// Retorna false se o hook não foi instalado por esta ferramenta.
+func Uninstall(dir, name string) (bool, error) {
+	path := filepath.Join(dir, name)
+	existing, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !strings.Contains(string(existing), marker) {
+		return false, nil
+	}
+
+	if err := os.Remove(path); err != nil {
+		return false, err
+	}
+	local := path + chainedSuffix
+	if _, err := os.Stat(local); err == nil {
+		if err := os.Rename(local, path); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// shellQuote protege um valor com aspas simples para o sh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
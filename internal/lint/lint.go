@@ -0,0 +1,110 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go-release-manager/internal/config"
+	"go-release-manager/internal/git"
+	"go-release-manager/internal/semver"
+)
+
+// Violation é uma regra não atendida por uma mensagem de commit
+type Violation struct {
+	Rule    string // ex: "header-format", "type", "scope"
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s [%s]", v.Message, v.Rule)
+}
+
+// scissorsLine é a linha a partir da qual o 'git commit --verbose' anexa o diff
+const scissorsLine = "# ------------------------ >8 ------------------------"
+
+// CleanMessage remove o que o git descarta ao gravar o commit: as linhas de
+// comentário ('#') e tudo depois da linha de tesoura do 'commit --verbose'.
+// Usado nas mensagens lidas do arquivo do hook commit-msg.
+func CleanMessage(message string) string {
+	message, _, _ = strings.Cut(message, scissorsLine)
+	lines := make([]string, 0)
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// Ignored informa se a mensagem é gerada pelo git e não precisa seguir o
+// Conventional Commits (merges e commits de fixup/squash para rebase)
+func Ignored(message string) bool {
+	for _, prefix := range []string{"Merge ", "fixup! ", "squash! ", "amend! "} {
+		if strings.HasPrefix(message, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Message valida uma mensagem de commit com o mesmo parser usado no cálculo
+// da versão e as regras de 'lint' do .go-releaserc.yml.
+func Message(cfg *config.Config, message string) []Violation {
+	violations := make([]Violation, 0)
+	subject, _, footers := git.ParseMessage(message)
+	if subject == "" {
+		return append(violations, Violation{Rule: "header-empty", Message: "a mensagem de commit está vazia"})
+	}
+
+	if max := cfg.Lint.MaxHeaderLength; max > 0 {
+		if n := utf8.RuneCountInString(subject); n > max {
+			violations = append(violations, Violation{Rule: "header-max-length",
+				Message: fmt.Sprintf("o cabeçalho tem %d caracteres (máximo: %d)", n, max)})
+		}
+	}
+
+	header, ok := semver.ParseHeader(subject)
+	if !ok {
+		return append(violations, Violation{Rule: "header-format",
+			Message: fmt.Sprintf("o cabeçalho '%s' não segue o formato 'tipo(escopo): descrição' e será ignorado no cálculo da versão", subject)})
+	}
+
+	if types := cfg.AllowedTypes(); !contains(types, header.Type) {
+		violations = append(violations, Violation{Rule: "type",
+			Message: fmt.Sprintf("tipo '%s' não permitido (use: %s)", header.Type, strings.Join(types, ", "))})
+	}
+	if scopes := cfg.Lint.Scopes; header.Scope != "" && len(scopes) > 0 && !contains(scopes, header.Scope) {
+		violations = append(violations, Violation{Rule: "scope",
+			Message: fmt.Sprintf("escopo '%s' não permitido (use: %s)", header.Scope, strings.Join(scopes, ", "))})
+	}
+	if strings.TrimSpace(header.Description) == "" {
+		violations = append(violations, Violation{Rule: "description-empty", Message: "a descrição depois de 'tipo:' está vazia"})
+	}
+
+	for _, required := range cfg.Lint.RequiredFooters {
+		found := false
+		for _, footer := range footers {
+			if strings.EqualFold(footer.Key, required) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			violations = append(violations, Violation{Rule: "footer-required",
+				Message: fmt.Sprintf("footer obrigatório '%s' ausente (ex: '%s: ...')", required, required)})
+		}
+	}
+	return violations
+}
+
+// contains informa se o valor está na lista
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GiteaProvider também atende servidores Forgejo (ex: Codeberg), que
+// mantêm a mesma API do Gitea.
+type GiteaProvider struct {
+	client   *http.Client
+	apiURL   string
+	token    string
+	owner    string
+	repoName string
+}
+
+// NewGiteaProvider cria um novo cliente para a API (v1) do Gitea/Forgejo.
+// baseURL é a URL do servidor (ex: https://gitea.empresa.com).
+func NewGiteaProvider(baseURL, token, owner, repoName string) *GiteaProvider {
+	return &GiteaProvider{
+		client:   http.DefaultClient,
+		apiURL:   strings.TrimSuffix(baseURL, "/") + "/api/v1",
+		token:    token,
+		owner:    owner,
+		repoName: repoName,
+	}
+}
+
+type giteaReleaseRequest struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+type giteaRelease struct {
+	ID      int64  `json:"id"`
+	HTMLURL string `json:"html_url"`
+}
+
+// CreateRelease implementa a interface Provider para o Gitea/Forgejo
+func (g *GiteaProvider) CreateRelease(ctx context.Context, tag, changelog string, prerelease bool) (string, error) {
+	release := giteaReleaseRequest{
+		TagName:    tag,
+		Name:       tag,
+		Body:       changelog,
+		Prerelease: prerelease,
+	}
+	var newRelease giteaRelease
+	if err := doJSON(ctx, g.client, http.MethodPost, g.repoURL("/releases"), g.header(), release, &newRelease); err != nil {
+		return "", err
+	}
+	return newRelease.HTMLURL, nil
+}
+
+// UploadAsset implementa a interface AssetUploader, anexando um arquivo ao
+// release da tag informada
+func (g *GiteaProvider) UploadAsset(ctx context.Context, tag, path string) error {
+	var release giteaRelease
+	if err := doJSON(ctx, g.client, http.MethodGet, g.repoURL("/releases/tags/"+url.PathEscape(tag)), g.header(), nil, &release); err != nil {
+		return fmt.Errorf("release da tag '%s' não encontrado: %v", tag, err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	name := filepath.Base(path)
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("attachment", name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return err
+	}
+	if err := form.Close(); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("/releases/%d/assets?name=%s", release.ID, url.QueryEscape(name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.repoURL(endpoint), &body)
+	if err != nil {
+		return err
+	}
+	req.Header = g.header()
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return send(g.client, req, nil)
+}
+
+// repoURL monta a URL de um endpoint do repositório
+func (g *GiteaProvider) repoURL(endpoint string) string {
+	return fmt.Sprintf("%s/repos/%s/%s%s", g.apiURL, url.PathEscape(g.owner), url.PathEscape(g.repoName), endpoint)
+}
+
+func (g *GiteaProvider) header() http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "token "+g.token)
+	return header
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGiteaUploadAsset(t *testing.T) {
+	asset := filepath.Join(t.TempDir(), "app_linux_amd64.tar.gz")
+	if err := os.WriteFile(asset, []byte("conteúdo do binário"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	uploaded := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "token gitea-123" {
+			t.Errorf("Authorization = %q", auth)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/repos/dono/repo/releases/tags/v1.2.0":
+			w.Write([]byte(`{"id":42,"html_url":"https://gitea.example.com/dono/repo/releases/tag/v1.2.0"}`))
+		case "POST /api/v1/repos/dono/repo/releases/42/assets":
+			if name := r.URL.Query().Get("name"); name != "app_linux_amd64.tar.gz" {
+				t.Errorf("name = %q", name)
+			}
+			file, header, err := r.FormFile("attachment")
+			if err != nil {
+				t.Errorf("campo 'attachment' ausente: %v", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			defer file.Close()
+			data, _ := io.ReadAll(file)
+			if header.Filename != "app_linux_amd64.tar.gz" || string(data) != "conteúdo do binário" {
+				t.Errorf("arquivo enviado = %s (%q)", header.Filename, data)
+			}
+			uploaded = true
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("requisição inesperada: %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := NewGiteaProvider(server.URL, "gitea-123", "dono", "repo")
+	if err := p.UploadAsset(context.Background(), "v1.2.0", asset); err != nil {
+		t.Fatal(err)
+	}
+	if !uploaded {
+		t.Error("o arquivo não foi enviado")
+	}
+}
+
+func TestGiteaUploadAssetWithoutRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("requisição inesperada sem release: %s %s", r.Method, r.URL)
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	p := NewGiteaProvider(server.URL, "gitea-123", "dono", "repo")
+	if err := p.UploadAsset(context.Background(), "v1.2.0", "inexistente.tar.gz"); err == nil {
+		t.Error("UploadAsset não retornou erro para um release inexistente")
+	}
+}
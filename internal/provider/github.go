@@ -25,12 +25,34 @@ func NewGitHubProvider(ctx context.Context, token, owner, repoName string) *GitH
 	}
 }
 
+// NewGitHubEnterpriseProvider cria um novo cliente para a API de um GitHub
+// Enterprise Server. baseURL é a URL do servidor (ex: https://github.empresa.com);
+// os sufixos '/api/v3/' e '/api/uploads/' são adicionados quando ausentes.
+// Se uploadURL for vazio, usa o mesmo servidor de baseURL.
+func NewGitHubEnterpriseProvider(ctx context.Context, baseURL, uploadURL, token, owner, repoName string) (*GitHubProvider, error) {
+	if uploadURL == "" {
+		uploadURL = baseURL
+	}
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	tc := oauth2.NewClient(ctx, ts)
+	client, err := github.NewClient(tc).WithEnterpriseURLs(baseURL, uploadURL)
+	if err != nil {
+		return nil, err
+	}
+	return &GitHubProvider{
+		client:   client,
+		owner:    owner,
+		repoName: repoName,
+	}, nil
+}
+
 // CreateRelease implementa a interface Provider para o GitHub
-func (g *GitHubProvider) CreateRelease(ctx context.Context, tag, changelog string) (string, error) {
+func (g *GitHubProvider) CreateRelease(ctx context.Context, tag, changelog string, prerelease bool) (string, error) {
 	release := &github.RepositoryRelease{
-		TagName: &tag,
-		Name:    &tag,
-		Body:    &changelog,
+		TagName:    &tag,
+		Name:       &tag,
+		Body:       &changelog,
+		Prerelease: &prerelease,
 	}
 	newRelease, _, err := g.client.Repositories.CreateRelease(ctx, g.owner, g.repoName, release)
 	if err != nil {
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type GitLabProvider struct {
+	client  *http.Client
+	apiURL  string
+	token   string
+	project string
+}
+
+// NewGitLabProvider cria um novo cliente para a API REST (v4) do GitLab.
+// baseURL é a URL do servidor (ex: https://gitlab.com) e project é o caminho
+// completo do projeto, incluindo subgrupos (ex: "grupo/subgrupo/projeto").
+func NewGitLabProvider(baseURL, token, project string) *GitLabProvider {
+	return &GitLabProvider{
+		client:  http.DefaultClient,
+		apiURL:  strings.TrimSuffix(baseURL, "/") + "/api/v4",
+		token:   token,
+		project: project,
+	}
+}
+
+type gitlabReleaseRequest struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type gitlabReleaseResponse struct {
+	Links struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+}
+
+// CreateRelease implementa a interface Provider para o GitLab.
+// O GitLab não possui o conceito de pré-release; a versão já carrega o
+// sufixo do canal (ex: v1.3.0-beta.1).
+func (g *GitLabProvider) CreateRelease(ctx context.Context, tag, changelog string, prerelease bool) (string, error) {
+	release := gitlabReleaseRequest{
+		TagName:     tag,
+		Name:        tag,
+		Description: changelog,
+	}
+	var newRelease gitlabReleaseResponse
+	if err := g.do(ctx, http.MethodPost, "/releases", release, &newRelease); err != nil {
+		return "", err
+	}
+	return newRelease.Links.Self, nil
+}
+
+// CreateTag implementa a interface TagCreator, criando a tag pela API
+func (g *GitLabProvider) CreateTag(ctx context.Context, tag, ref, message string) error {
+	body := map[string]string{
+		"tag_name": tag,
+		"ref":      ref,
+		"message":  message,
+	}
+	return g.do(ctx, http.MethodPost, "/repository/tags", body, nil)
+}
+
+// do envia uma requisição JSON para um endpoint do projeto
+func (g *GitLabProvider) do(ctx context.Context, method, endpoint string, in, out any) error {
+	reqURL := fmt.Sprintf("%s/projects/%s%s", g.apiURL, url.PathEscape(g.project), endpoint)
+	header := http.Header{}
+	header.Set("PRIVATE-TOKEN", g.token)
+	return doJSON(ctx, g.client, method, reqURL, header, in, out)
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitLabCreateRelease(t *testing.T) {
+	var got gitlabReleaseRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Os subgrupos fazem parte do ID do projeto, com as barras escapadas
+		if path := r.URL.EscapedPath(); r.Method != http.MethodPost || path != "/api/v4/projects/grupo%2Fsubgrupo%2Fprojeto/releases" {
+			t.Errorf("requisição inesperada: %s %s", r.Method, path)
+			http.NotFound(w, r)
+			return
+		}
+		if token := r.Header.Get("PRIVATE-TOKEN"); token != "glpat-123" {
+			t.Errorf("PRIVATE-TOKEN = %q", token)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("corpo inválido: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"tag_name":"v1.2.0","_links":{"self":"https://gitlab.example.com/grupo/subgrupo/projeto/-/releases/v1.2.0"}}`))
+	}))
+	defer server.Close()
+
+	p := NewGitLabProvider(server.URL+"/", "glpat-123", "grupo/subgrupo/projeto")
+	releaseURL, err := p.CreateRelease(context.Background(), "v1.2.0", "## v1.2.0", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://gitlab.example.com/grupo/subgrupo/projeto/-/releases/v1.2.0"; releaseURL != want {
+		t.Errorf("URL do release = %q, esperado %q", releaseURL, want)
+	}
+	if got.TagName != "v1.2.0" || got.Name != "v1.2.0" || got.Description != "## v1.2.0" {
+		t.Errorf("release enviado = %+v", got)
+	}
+}
+
+func TestGitLabCreateTag(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if path := r.URL.EscapedPath(); path != "/api/v4/projects/dono%2Fprojeto/repository/tags" {
+			t.Errorf("requisição inesperada: %s %s", r.Method, path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	p := NewGitLabProvider(server.URL, "glpat-123", "dono/projeto")
+	if err := p.CreateTag(context.Background(), "v1.2.0", "abc1234", "Release v1.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"tag_name": "v1.2.0", "ref": "abc1234", "message": "Release v1.2.0"}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %q, esperado %q", key, got[key], value)
+		}
+	}
+}
+
+func TestGitLabError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"403 Forbidden"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	p := NewGitLabProvider(server.URL, "glpat-123", "dono/projeto")
+	if _, err := p.CreateRelease(context.Background(), "v1.2.0", "", false); err == nil {
+		t.Error("CreateRelease não retornou erro para uma resposta 403")
+	}
+}
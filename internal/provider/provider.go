@@ -1,8 +1,105 @@
 package provider
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-release-manager/internal/config"
+	"go-release-manager/internal/git"
+)
+
+// Tipos de provedor suportados
+const (
+	TypeGitHub = "github"
+	TypeGitLab = "gitlab"
+	TypeGitea  = "gitea"
+)
 
 // Provider define a interface para interagir com serviços como GitHub, GitLab, etc.
 type Provider interface {
-	CreateRelease(ctx context.Context, tag, changelog string) (string, error)
+	// CreateRelease cria o release para uma tag já existente no remoto e
+	// retorna a URL do release criado.
+	CreateRelease(ctx context.Context, tag, changelog string, prerelease bool) (string, error)
+}
+
+// TagCreator é implementado pelos provedores que conseguem criar a tag
+// diretamente pela API, sem um 'git push'.
+type TagCreator interface {
+	CreateTag(ctx context.Context, tag, ref, message string) error
+}
+
+// AssetUploader é implementado pelos provedores que conseguem anexar
+// arquivos (ex: binários) a um release já criado.
+type AssetUploader interface {
+	UploadAsset(ctx context.Context, tag, path string) error
+}
+
+// DetectType decide qual provedor usar. O 'type' do .go-releaserc.yml tem
+// prioridade; caso contrário, o tipo é inferido pelo host do remote.
+func DetectType(cfg config.Provider, remote *git.Remote) string {
+	if cfg.Type != "" {
+		providerType := strings.ToLower(cfg.Type)
+		if providerType == "forgejo" {
+			return TypeGitea
+		}
+		return providerType
+	}
+	if remote == nil {
+		return TypeGitHub
+	}
+
+	host := strings.ToLower(remote.Host)
+	switch {
+	case strings.Contains(host, "gitlab"):
+		return TypeGitLab
+	case strings.Contains(host, "gitea"), strings.Contains(host, "forgejo"), strings.Contains(host, "codeberg"):
+		return TypeGitea
+	default:
+		return TypeGitHub
+	}
+}
+
+// New cria o provedor do tipo informado para o repositório do remote
+func New(ctx context.Context, providerType string, cfg config.Provider, remote *git.Remote, token string) (Provider, error) {
+	switch providerType {
+	case TypeGitHub:
+		if cfg.URL == "" && (remote.Host == "" || remote.Host == "github.com") {
+			return NewGitHubProvider(ctx, token, remote.Owner, remote.Repo), nil
+		}
+		// Qualquer outro host é tratado como GitHub Enterprise Server
+		return NewGitHubEnterpriseProvider(ctx, baseURL(cfg, remote), cfg.UploadURL, token, remote.Owner, remote.Repo)
+	case TypeGitLab:
+		return NewGitLabProvider(baseURL(cfg, remote), token, remote.Path()), nil
+	case TypeGitea:
+		return NewGiteaProvider(baseURL(cfg, remote), token, remote.Owner, remote.Repo), nil
+	default:
+		return nil, fmt.Errorf("provedor desconhecido: '%s'", providerType)
+	}
+}
+
+// baseURL retorna a URL do servidor definida no config ou, se ausente, a
+// inferida pelo host do remote
+func baseURL(cfg config.Provider, remote *git.Remote) string {
+	if cfg.URL != "" {
+		return cfg.URL
+	}
+	return "https://" + remote.Host
+}
+
+// CompareURL retorna a página que compara duas tags no provedor
+// (ex: https://github.com/dono/repo/compare/v1.4.0...v1.5.0). Sem tag
+// anterior, retorna a página da própria tag.
+func CompareURL(providerType string, cfg config.Provider, remote *git.Remote, from, to string) string {
+	repoURL := strings.TrimSuffix(baseURL(cfg, remote), "/") + "/" + remote.Path()
+	switch {
+	case from == "" && providerType == TypeGitLab:
+		return repoURL + "/-/tags/" + to
+	case from == "":
+		return repoURL + "/releases/tag/" + to
+	case providerType == TypeGitLab:
+		return repoURL + "/-/compare/" + from + "..." + to
+	default:
+		return repoURL + "/compare/" + from + "..." + to
+	}
 }
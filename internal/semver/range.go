@@ -0,0 +1,91 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-release-manager/internal/git"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// VersionRange é a faixa de versões de um branch de manutenção: "1.x" aceita
+// qualquer versão 1.y.z e "1.4.x" apenas as versões 1.4.z.
+type VersionRange struct {
+	Major    uint64
+	Minor    uint64
+	HasMinor bool
+}
+
+// ParseRange lê uma faixa no formato "1.x", "v1.x" ou "1.4.x"
+func ParseRange(s string) (*VersionRange, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(parts) < 2 || len(parts) > 3 || parts[len(parts)-1] != "x" {
+		return nil, fmt.Errorf("faixa inválida '%s' (use o formato '1.x' ou '1.4.x')", s)
+	}
+	major, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("faixa inválida '%s': %v", s, err)
+	}
+	r := &VersionRange{Major: major}
+	if len(parts) == 3 {
+		minor, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("faixa inválida '%s': %v", s, err)
+		}
+		r.Minor = minor
+		r.HasMinor = true
+	}
+	return r, nil
+}
+
+// Contains informa se a versão pertence à faixa
+func (r *VersionRange) Contains(v *semver.Version) bool {
+	if v.Major() != r.Major {
+		return false
+	}
+	return !r.HasMinor || v.Minor() == r.Minor
+}
+
+// MaxIncrement retorna o maior incremento permitido dentro da faixa
+// ("1.x" -> Minor, "1.4.x" -> Patch)
+func (r *VersionRange) MaxIncrement() Increment {
+	if r.HasMinor {
+		return IncrementPatch
+	}
+	return IncrementMinor
+}
+
+func (r *VersionRange) String() string {
+	if r.HasMinor {
+		return fmt.Sprintf("%d.%d.x", r.Major, r.Minor)
+	}
+	return fmt.Sprintf("%d.x", r.Major)
+}
+
+// checkRange garante que a próxima versão de um branch de manutenção continue
+// na faixa configurada e não colida com uma tag já publicada em outra linha
+// (ex: um 'feat' em release/1.4.x que geraria v1.5.0).
+func checkRange(r *VersionRange, format git.TagFormat, latest *semver.Version, next semver.Version, increment Increment) error {
+	if !r.Contains(&next) {
+		if !r.Contains(latest) {
+			return fmt.Errorf("a última versão %s está fora da faixa '%s' deste branch de manutenção", latest, r)
+		}
+		return fmt.Errorf("o incremento %s levaria %s para %s, fora da faixa '%s' deste branch de manutenção. "+
+			"Nesta linha só são aceitos incrementos até %s; faça essa mudança no branch principal e traga para cá apenas as alterações compatíveis",
+			increment, latest, next.String(), r, r.MaxIncrement())
+	}
+
+	tag := format.Tag(next.String())
+	tags, err := git.ListVersionTags(format, false)
+	if err != nil {
+		return fmt.Errorf("erro ao listar as tags: %v", err)
+	}
+	for _, t := range tags {
+		if t.Name == tag {
+			return fmt.Errorf("a tag %s já existe em outro branch; a versão calculada neste branch de manutenção colidiria com ela", tag)
+		}
+	}
+	return nil
+}
@@ -28,32 +28,28 @@ func (i Increment) String() string {
 
 var commitRegex = regexp.MustCompile(`^(\w+)(?:\(([^)]+)\))?(!?): (.*)$`)
 
-func parseCommit(rawCommit string) (header string, body string, footers string) {
-	// (Esta função permanece 100% intacta)
-	commit := strings.TrimSpace(rawCommit)
-	parts := strings.SplitN(commit, "\n\n", 2)
-	if len(parts) == 0 {
-		return "", "", ""
-	}
-	header = strings.Split(parts[0], "\n")[0]
-	if len(parts) == 1 {
-		return header, "", ""
-	}
-	bodyAndFooters := parts[1]
-	paragraphs := regexp.MustCompile("\n\n").Split(bodyAndFooters, -1)
-	footerStartIndex := len(paragraphs)
-	for i := len(paragraphs) - 1; i >= 0; i-- {
-		p := paragraphs[i]
-		isFooter, _ := regexp.MatchString(`^([\w-]+): |^(BREAKING CHANGE): |^(BREAKING-CHANGE): `, p)
-		if isFooter {
-			footerStartIndex = i
-		} else {
-			break
-		}
+// Header é o cabeçalho de um commit convencional: "tipo(escopo)!: descrição"
+type Header struct {
+	Type        string
+	Scope       string
+	Breaking    bool // '!' antes dos dois pontos
+	Description string
+}
+
+// ParseHeader analisa o cabeçalho (primeira linha) de um commit. Retorna
+// false se ele não segue o Conventional Commits. É o mesmo parser usado no
+// cálculo da versão e pelo comando 'lint'.
+func ParseHeader(header string) (Header, bool) {
+	matches := commitRegex.FindStringSubmatch(header)
+	if matches == nil {
+		return Header{}, false
 	}
-	body = strings.Join(paragraphs[:footerStartIndex], "\n\n")
-	footers = strings.Join(paragraphs[footerStartIndex:], "\n\n")
-	return header, body, footers
+	return Header{
+		Type:        matches[1],
+		Scope:       matches[2],
+		Breaking:    matches[3] == "!",
+		Description: matches[4],
+	}, true
 }
 
 // --- NOVA FUNÇÃO AUXILIAR ---
@@ -81,116 +77,134 @@ func mapConfigToIncrements(rules []config.ReleaseRule) map[string]Increment {
 	return ruleMap
 }
 
-// --- ASSINATURA ATUALIZADA ---
-// Agora recebe 'cfg *config.Config' como o primeiro parâmetro
-func DetermineNextVersion(cfg *config.Config, latestTag string, commits []string, preReleaseChannel string) (string, Increment, error) {
-
-	// 1. Parse da última tag (Intacto)
-	if latestTag == "v0.0.0" {
-		latestTag = "0.0.0"
-	}
-	v, err := semver.NewVersion(strings.TrimPrefix(latestTag, "v"))
-	if err != nil {
-		return "", IncrementNone, fmt.Errorf("erro ao analisar a última tag '%s': %v", latestTag, err)
-	}
+// Change é um commit convencional já analisado. É o mesmo dado usado para
+// decidir o incremento de versão e para gerar o changelog.
+type Change struct {
+	Commit      git.Commit
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+	// BreakingNote é o texto do footer 'BREAKING CHANGE:', se houver.
+	BreakingNote string
+	Increment    Increment
+}
 
-	// --- 2. LÓGICA DE INCREMENTO ATUALIZADA ---
-	highestIncrement := IncrementNone
+// AnalyzeCommits classifica cada commit convencional de acordo com as regras
+// do config. Commits não convencionais são ignorados.
+func AnalyzeCommits(cfg *config.Config, commits []git.Commit) []Change {
 	// Converte as regras do .yml em um mapa de consulta
 	releaseRules := mapConfigToIncrements(cfg.ReleaseRules)
 
 	log.Printf("Iniciando análise de %d commits...", len(commits))
+	changes := make([]Change, 0, len(commits))
 	for _, commit := range commits {
-		cleanCommit := strings.TrimSpace(commit)
-		if cleanCommit == "" {
+		header := commit.Subject
+		if header == "" {
 			continue
 		}
-		header, _, footers := parseCommit(cleanCommit)
 		log.Printf("Analisando header: [%.70s]", header)
 
-		matches := commitRegex.FindStringSubmatch(header)
-		if matches == nil {
+		parsed, ok := ParseHeader(header)
+		if !ok {
 			log.Printf("Commit não convencional, ignorando: [%.70s]", header)
 			continue
 		}
 
-		commitType := matches[1]
-		isHeaderBreaking := matches[3] == "!"
-
-		// Lógica de Breaking Change (permanece intacta, 'breaking' sempre vence)
-		isFooterBreaking := false
-		if footers != "" {
-			footerLines := strings.Split(footers, "\n")
-			for _, line := range footerLines {
-				trimmedLine := strings.TrimSpace(line)
-				if strings.HasPrefix(trimmedLine, "BREAKING CHANGE:") || strings.HasPrefix(trimmedLine, "BREAKING-CHANGE:") {
-					isFooterBreaking = true
-					log.Println("Encontrado 'BREAKING CHANGE' no footer.")
-					break
-				}
-			}
+		change := Change{
+			Commit:      commit,
+			Type:        parsed.Type,
+			Scope:       parsed.Scope,
+			Description: parsed.Description,
 		}
-		isBreaking := isHeaderBreaking || isFooterBreaking
-
-		// --- LÓGICA DE INCREMENTO SUBSTITUÍDA ---
-		// Em vez de 'if/else' para 'feat' e 'fix', usamos o mapa de regras
-		if isBreaking {
-			if highestIncrement < IncrementMajor {
-				highestIncrement = IncrementMajor
-			}
+		isHeaderBreaking := parsed.Breaking
+
+		// Lógica de Breaking Change ('breaking' sempre vence)
+		note, isFooterBreaking := commit.BreakingChangeNote()
+		if isFooterBreaking {
+			change.BreakingNote = note
+			log.Println("Encontrado 'BREAKING CHANGE' no footer.")
+		}
+		change.Breaking = isHeaderBreaking || isFooterBreaking
+
+		if change.Breaking {
+			change.Increment = IncrementMajor
 		} else {
-			// Consulta o tipo de commit (ex: "docs") no mapa de regras
-			inc, ok := releaseRules[commitType]
-			if !ok {
-				// Se o tipo não estiver no mapa (ex: "security"), não faz nada
-				inc = IncrementNone
-			}
-
-			// Atualiza o incremento mais alto encontrado
-			if inc > highestIncrement {
-				highestIncrement = inc
-			}
+			// Consulta o tipo de commit (ex: "docs") no mapa de regras.
+			// Se o tipo não estiver no mapa (ex: "security"), não gera release.
+			change.Increment = releaseRules[change.Type]
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// HighestIncrement retorna o maior incremento entre as mudanças analisadas
+func HighestIncrement(changes []Change) Increment {
+	highestIncrement := IncrementNone
+	for _, change := range changes {
+		if change.Increment > highestIncrement {
+			highestIncrement = change.Increment
 		}
-		// --- FIM DA LÓGICA SUBSTITUÍDA ---
 	}
+	return highestIncrement
+}
+
+// DetermineNextVersion calcula a próxima tag a partir da última tag e das
+// mudanças retornadas por AnalyzeCommits. O formato da tag (ex: "v${version}",
+// "release-${version}") é usado para ler a última tag e montar a nova.
+// Em um branch de manutenção, versionRange (ex: 1.x) limita a nova versão;
+// nil significa sem limite.
+func DetermineNextVersion(cfg *config.Config, format git.TagFormat, latestTag string, changes []Change, preReleaseChannel string, versionRange *VersionRange) (string, Increment, error) {
+
+	// 1. Parse da última tag
+	latestVersion, ok := format.Version(latestTag)
+	if !ok {
+		return "", IncrementNone, fmt.Errorf("a última tag '%s' não segue o formato '%s'", latestTag, format)
+	}
+	v, err := semver.NewVersion(latestVersion)
+	if err != nil {
+		return "", IncrementNone, fmt.Errorf("erro ao analisar a última tag '%s': %v", latestTag, err)
+	}
+
+	// 2. O maior incremento vence
+	highestIncrement := HighestIncrement(changes)
 	log.Printf("Análise concluída. Maior incremento: %s", highestIncrement)
 
 	// 3. Se nenhum incremento for encontrado (Intacto)
 	if highestIncrement == IncrementNone {
-		return "v" + v.String(), IncrementNone, nil
+		return format.Tag(v.String()), IncrementNone, nil
 	}
 
-	// 4. Calcular a nova versão ESTÁVEL (Intacto)
-	var nextStableVersion semver.Version
-	switch highestIncrement {
-	case IncrementMajor:
-		if v.Major() == 0 {
-			nextStableVersion = v.IncMajor()
-		} else {
-			nextStableVersion = v.IncMajor()
+	// 4. Calcular a nova versão ESTÁVEL
+	nextStableVersion := nextStable(v, highestIncrement)
+	if versionRange != nil {
+		if err := checkRange(versionRange, format, v, nextStableVersion, highestIncrement); err != nil {
+			return "", highestIncrement, err
 		}
-	case IncrementMinor:
-		nextStableVersion = v.IncMinor()
-	case IncrementPatch:
-		nextStableVersion = v.IncPatch()
 	}
 
 	// 5. LÓGICA DE PRÉ-RELEASE (Intacta, já funciona com a lógica acima)
 	if preReleaseChannel == "" {
-		return "v" + nextStableVersion.String(), highestIncrement, nil
+		return format.Tag(nextStableVersion.String()), highestIncrement, nil
+	}
+
+	// 6. Validar a troca de canal e se a base mudou desde a última pré-release
+	if err := checkPreReleaseChannel(cfg, format, v, nextStableVersion, preReleaseChannel); err != nil {
+		return "", highestIncrement, err
 	}
 
-	baseVersionStr := "v" + nextStableVersion.String()
-	latestPreTagString, err := git.GetLatestPreReleaseTag(baseVersionStr, preReleaseChannel)
+	baseVersionStr := nextStableVersion.String()
+	latestPreTagString, err := git.GetLatestPreReleaseTag(format, baseVersionStr, preReleaseChannel)
 	if err != nil {
 		return "", highestIncrement, fmt.Errorf("erro ao buscar tags de pré-release: %v", err)
 	}
 
 	var nextVersionString string
 	if latestPreTagString == "" {
-		nextVersionString = fmt.Sprintf("%s-%s.1", baseVersionStr, preReleaseChannel)
+		nextVersionString = format.Tag(fmt.Sprintf("%s-%s.1", baseVersionStr, preReleaseChannel))
 	} else {
-		vPre, err := semver.NewVersion(strings.TrimPrefix(latestPreTagString, "v"))
+		vPre, err := semver.NewVersion(latestPreTagString)
 		if err != nil {
 			return "", highestIncrement, fmt.Errorf("erro ao analisar tag de pré-release '%s': %v", latestPreTagString, err)
 		}
@@ -209,8 +223,113 @@ func DetermineNextVersion(cfg *config.Config, latestTag string, commits []string
 		if err != nil {
 			return "", highestIncrement, fmt.Errorf("erro ao definir pré-release '%s': %v", prStr, err)
 		}
-		nextVersionString = "v" + vNextPre.String()
+		nextVersionString = format.Tag(vNextPre.String())
 	}
 
 	return nextVersionString, highestIncrement, nil
 }
+
+// checkPreReleaseChannel compara o canal pedido com a última pré-release
+// alcançável a partir de HEAD (a partir da última tag, inclusive). Na mesma versão base, os
+// canais só podem avançar na ordem de 'preReleaseChannels' (ex: alpha < beta
+// < rc). Se novos commits exigirem uma base maior (ex: um breaking change
+// depois de v1.3.0-beta.2), a nova base é usada e o contador recomeça.
+func checkPreReleaseChannel(cfg *config.Config, format git.TagFormat, latest *semver.Version, nextStable semver.Version, channel string) error {
+	channels := cfg.PreReleaseChannels
+	if len(channels) > 0 && channelRank(channels, channel) < 0 {
+		return fmt.Errorf("canal de pré-release '%s' não está em preReleaseChannels (%s)", channel, strings.Join(channels, " < "))
+	}
+
+	tags, err := git.ListVersionTags(format, true)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar tags de pré-release: %v", err)
+	}
+	var previous *git.VersionTag
+	for i, tag := range tags {
+		// Com includePrereleases, 'latest' pode ser a própria pré-release anterior
+		if tag.Version.Prerelease() == "" || tag.Version.LessThan(latest) {
+			continue
+		}
+		if previous == nil || tag.Version.GreaterThan(previous.Version) {
+			previous = &tags[i]
+		}
+	}
+	if previous == nil {
+		return nil
+	}
+
+	previousBase := StableOf(previous.Version)
+	if previousBase != nextStable.String() {
+		log.Printf("Base da pré-release recalculada: %s -> %s (os commits desde %s exigem um incremento maior).",
+			previousBase, nextStable.String(), previous.Name)
+		return nil
+	}
+
+	previousChannel := ChannelOf(previous.Version)
+	if len(channels) > 0 && channelRank(channels, channel) < channelRank(channels, previousChannel) {
+		return fmt.Errorf("não é possível voltar do canal '%s' (%s) para '%s': a ordem dos canais é %s",
+			previousChannel, previous.Name, channel, strings.Join(channels, " < "))
+	}
+	if previousChannel != channel {
+		log.Printf("Trocando de canal: %s -> %s (a partir de %s).", previousChannel, channel, previous.Name)
+	}
+	return nil
+}
+
+// ChannelOf retorna o canal de uma pré-release (ex: 1.3.0-beta.2 -> "beta")
+func ChannelOf(v *semver.Version) string {
+	channel, _, _ := strings.Cut(v.Prerelease(), ".")
+	return channel
+}
+
+// channelRank retorna a posição do canal na ordem configurada, ou -1
+func channelRank(channels []string, channel string) int {
+	for i, c := range channels {
+		if c == channel {
+			return i
+		}
+	}
+	return -1
+}
+
+// StableOf retorna a versão estável correspondente a uma pré-release
+// (ex: 1.3.0-rc.4 -> 1.3.0)
+func StableOf(v *semver.Version) string {
+	return semver.New(v.Major(), v.Minor(), v.Patch(), "", "").String()
+}
+
+// nextStable aplica o incremento à versão base. Quando a base é uma
+// pré-release (ex: 1.3.0-rc.1), a versão estável 1.3.0 ainda não foi
+// publicada e já comporta incrementos até o que ela representa: um 'fix' ou
+// 'feat' depois de 1.3.0-rc.1 resulta em 1.3.0, e não em 1.4.0.
+func nextStable(v *semver.Version, increment Increment) semver.Version {
+	base := v
+	if v.Prerelease() != "" {
+		base = semver.New(v.Major(), v.Minor(), v.Patch(), "", "")
+		if increment <= impliedIncrement(base) {
+			return *base
+		}
+	}
+
+	switch increment {
+	case IncrementMajor:
+		return base.IncMajor()
+	case IncrementMinor:
+		return base.IncMinor()
+	default:
+		return base.IncPatch()
+	}
+}
+
+// impliedIncrement retorna o incremento que uma versão estável representa
+// (1.3.1 -> Patch, 1.3.0 -> Minor, 2.0.0 -> Major)
+func impliedIncrement(v *semver.Version) Increment {
+	switch {
+	case v.Patch() > 0:
+		return IncrementPatch
+	case v.Minor() > 0:
+		return IncrementMinor
+	default:
+		return IncrementMajor
+	}
+}
@@ -0,0 +1,66 @@
+package versionfile
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// Update grava a versão no arquivo. Com um padrão (expressão regular), o
+// primeiro grupo de captura de cada ocorrência é substituído pela versão
+// (ex: 'Version = "(.*)"' em version.go); sem padrão, o conteúdo inteiro do
+// arquivo passa a ser a versão (ex: VERSION).
+// Retorna false se o arquivo já continha a versão.
+func Update(path, pattern, version string) (bool, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	var out []byte
+	if pattern == "" {
+		out = []byte(version + "\n")
+	} else {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("padrão inválido '%s': %v", pattern, err)
+		}
+		out, err = replaceFirstGroup(re, src, []byte(version))
+		if err != nil {
+			return false, fmt.Errorf("%s: %v", path, err)
+		}
+	}
+
+	if bytes.Equal(src, out) {
+		return false, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path, out, info.Mode().Perm())
+}
+
+// replaceFirstGroup troca o primeiro grupo de captura de cada ocorrência do
+// padrão, preservando o resto do conteúdo
+func replaceFirstGroup(re *regexp.Regexp, src, version []byte) ([]byte, error) {
+	matches := re.FindAllSubmatchIndex(src, -1)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("o padrão '%s' não foi encontrado", re)
+	}
+
+	var out bytes.Buffer
+	last := 0
+	for _, m := range matches {
+		// m[2], m[3]: início e fim do primeiro grupo (-1 se não participou)
+		if m[2] < 0 {
+			continue
+		}
+		out.Write(src[last:m[2]])
+		out.Write(version)
+		last = m[3]
+	}
+	out.Write(src[last:])
+	return out.Bytes(), nil
+}